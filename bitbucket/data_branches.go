@@ -0,0 +1,118 @@
+package bitbucket
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	bitbucketTypes "github.com/xvlcwk-terraform/terraform-provider-bitbucketserver/bitbucket/util/types"
+	"net/url"
+	"strconv"
+)
+
+func dataSourceBranches() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceBranchesRead,
+
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"repository": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"filter_text": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return branches containing this text.",
+			},
+			"base": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Branch or tag the branches are compared to when details are requested, the default branch if omitted.",
+			},
+			"details": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Also return how many commits each branch is ahead and behind of the base.",
+			},
+			"branches": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"display_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"latest_commit": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"is_default": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"ahead": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"behind": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceBranchesRead(d *schema.ResourceData, m interface{}) error {
+	project := d.Get("project").(string)
+	repository := d.Get("repository").(string)
+	filterText := d.Get("filter_text").(string)
+	base := d.Get("base").(string)
+	details := d.Get("details").(bool)
+
+	resourceURL := fmt.Sprintf("%s?details=%s",
+		getRepositoryRefsURI(project, repository, "branches"),
+		strconv.FormatBool(details),
+	)
+	if filterText != "" {
+		resourceURL += "&filterText=" + url.QueryEscape(filterText)
+	}
+	if base != "" {
+		resourceURL += "&base=" + url.QueryEscape(base)
+	}
+
+	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+	branches, err := readAllPages[GitRef](client, resourceURL)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", project, repository))
+
+	var terraformBranches []interface{}
+	for _, branch := range branches {
+		aheadBehind := branch.aheadBehind()
+
+		b := make(map[string]interface{})
+		b["id"] = branch.ID
+		b["display_id"] = branch.DisplayID
+		b["latest_commit"] = branch.LatestCommit
+		b["is_default"] = branch.IsDefault
+		b["ahead"] = aheadBehind.Ahead
+		b["behind"] = aheadBehind.Behind
+		terraformBranches = append(terraformBranches, b)
+	}
+
+	_ = d.Set("branches", terraformBranches)
+	return nil
+}
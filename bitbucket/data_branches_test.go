@@ -0,0 +1,69 @@
+package bitbucket
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccBitbucketDataBranches(t *testing.T) {
+	projectKey := fmt.Sprintf("TEST%v", rand.New(rand.NewSource(time.Now().UnixNano())).Int())
+
+	config := fmt.Sprintf(`
+		resource "bitbucketserver_project" "test" {
+			key  = "%v"
+			name = "test-project-%v"
+		}
+
+		resource "bitbucketserver_repository" "test" {
+			project        = bitbucketserver_project.test.key
+			name           = "repo"
+			default_branch = "main"
+
+			initialize {
+				readme = "# repo"
+			}
+		}
+
+		resource "bitbucketserver_branch" "release" {
+			project     = bitbucketserver_project.test.key
+			repository  = bitbucketserver_repository.test.slug
+			name        = "release/1.0"
+			start_point = "main"
+		}
+
+		data "bitbucketserver_branches" "all" {
+			project    = bitbucketserver_project.test.key
+			repository = bitbucketserver_repository.test.slug
+			details    = true
+			depends_on = [bitbucketserver_branch.release]
+		}
+
+		data "bitbucketserver_branches" "release" {
+			project     = bitbucketserver_project.test.key
+			repository  = bitbucketserver_repository.test.slug
+			filter_text = "release/"
+			depends_on  = [bitbucketserver_branch.release]
+		}
+	`, projectKey, projectKey)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bitbucketserver_branches.all", "branches.#", "2"),
+					resource.TestCheckResourceAttr("data.bitbucketserver_branches.release", "branches.#", "1"),
+					resource.TestCheckResourceAttr("data.bitbucketserver_branches.release", "branches.0.display_id", "release/1.0"),
+					resource.TestCheckResourceAttr("data.bitbucketserver_branches.release", "branches.0.is_default", "false"),
+					resource.TestCheckResourceAttrPair("data.bitbucketserver_branches.release", "branches.0.latest_commit", "bitbucketserver_branch.release", "latest_commit"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,79 @@
+package bitbucket
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	bitbucketTypes "github.com/xvlcwk-terraform/terraform-provider-bitbucketserver/bitbucket/util/types"
+)
+
+func dataSourceCommit() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceCommitRead,
+
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"repository": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"ref": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Branch, tag or commit to read the latest commit of, the default branch if omitted.",
+			},
+			"commit_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"display_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"message": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"author_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"author_email": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"author_timestamp": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceCommitRead(d *schema.ResourceData, m interface{}) error {
+	project := d.Get("project").(string)
+	repository := d.Get("repository").(string)
+	ref := d.Get("ref").(string)
+
+	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+	commit, err := readLatestCommit(client, project, repository, ref, "")
+	if err != nil {
+		return err
+	}
+
+	if commit == nil {
+		return fmt.Errorf("no commit found for ref %q in repository %s/%s", ref, project, repository)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", project, repository, commit.ID))
+	_ = d.Set("commit_id", commit.ID)
+	_ = d.Set("display_id", commit.DisplayID)
+	_ = d.Set("message", commit.Message)
+	_ = d.Set("author_name", commit.Author.Name)
+	_ = d.Set("author_email", commit.Author.EmailAddress)
+	_ = d.Set("author_timestamp", commit.AuthorTimestamp)
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package bitbucket
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccBitbucketDataCommit(t *testing.T) {
+	projectKey := fmt.Sprintf("TEST%v", rand.New(rand.NewSource(time.Now().UnixNano())).Int())
+
+	config := fmt.Sprintf(`
+		resource "bitbucketserver_project" "test" {
+			key  = "%v"
+			name = "test-project-%v"
+		}
+
+		resource "bitbucketserver_repository" "test" {
+			project        = bitbucketserver_project.test.key
+			name           = "repo"
+			default_branch = "main"
+
+			initialize {
+				readme = "# repo"
+			}
+		}
+
+		resource "bitbucketserver_repository_file" "test" {
+			project        = bitbucketserver_project.test.key
+			repository     = bitbucketserver_repository.test.slug
+			path           = "CHANGELOG.md"
+			content        = "# Changelog"
+			commit_message = "Add the changelog"
+		}
+
+		data "bitbucketserver_commit" "test" {
+			project    = bitbucketserver_project.test.key
+			repository = bitbucketserver_repository.test.slug
+			ref        = "main"
+			depends_on = [bitbucketserver_repository_file.test]
+		}
+	`, projectKey, projectKey)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.bitbucketserver_commit.test", "commit_id", "bitbucketserver_repository_file.test", "last_commit_id"),
+					resource.TestCheckResourceAttr("data.bitbucketserver_commit.test", "message", "Add the changelog"),
+					resource.TestCheckResourceAttrSet("data.bitbucketserver_commit.test", "author_timestamp"),
+				),
+			},
+		},
+	})
+}
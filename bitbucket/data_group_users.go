@@ -10,6 +10,7 @@ import (
 )
 
 type PaginatedGroupUsersValue struct {
+	Id           int    `json:"id,omitempty"`
 	Name         string `json:"name,omitempty"`
 	EmailAddress string `json:"emailAddress,omitempty"`
 	DisplayName  string `json:"displayName,omitempty"`
@@ -17,6 +18,7 @@ type PaginatedGroupUsersValue struct {
 }
 
 type GroupUser struct {
+	Id           int
 	Name         string
 	EmailAddress string
 	DisplayName  string
@@ -123,6 +125,7 @@ func readGroupUsers(m interface{}, group string, filter string) ([]GroupUser, er
 
 		for _, user := range groupUsers.Values {
 			g := GroupUser{
+				Id:           user.Id,
 				Name:         user.Name,
 				EmailAddress: user.EmailAddress,
 				DisplayName:  user.DisplayName,
@@ -133,7 +136,7 @@ func readGroupUsers(m interface{}, group string, filter string) ([]GroupUser, er
 
 		if groupUsers.IsLastPage == false {
 			resourceURL = fmt.Sprintf("/rest/api/1.0/admin/groups/more-members?context=%s&limit=100&start=%d",
-				group,
+				url.QueryEscape(group),
 				groupUsers.NextPageStart,
 			)
 
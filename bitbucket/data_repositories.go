@@ -0,0 +1,101 @@
+package bitbucket
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/xvlcwk-terraform/terraform-provider-bitbucketserver/bitbucket/util/client"
+	bitbucketTypes "github.com/xvlcwk-terraform/terraform-provider-bitbucketserver/bitbucket/util/types"
+	"net/url"
+	"strings"
+)
+
+func dataSourceRepositories() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceRepositoriesRead,
+
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				AtLeastOneOf: []string{"project", "label"},
+				Description:  "Only return repositories of this project.",
+			},
+			"label": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return repositories with this label.",
+			},
+			"repositories": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"project": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"slug": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRepositoriesRead(d *schema.ResourceData, m interface{}) error {
+	project := d.Get("project").(string)
+	label := d.Get("label").(string)
+
+	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+
+	var repositories []Repository
+	var err error
+	if label != "" {
+		repositories, err = readAllPages[Repository](client, fmt.Sprintf("/rest/api/latest/labels/%s/labeled?type=REPOSITORY", url.PathEscape(label)))
+	} else {
+		repositories, err = readProjectRepositories(client, project)
+	}
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", project, label))
+
+	var terraformRepositories []interface{}
+	for _, repository := range repositories {
+		repositoryProject := ""
+		if repository.Project != nil {
+			repositoryProject = repository.Project.Key
+		}
+
+		// the labeled endpoint covers all projects, so the project filter is applied here
+		if project != "" && !strings.EqualFold(repositoryProject, project) {
+			continue
+		}
+
+		r := make(map[string]interface{})
+		r["project"] = repositoryProject
+		r["slug"] = repository.Slug
+		r["name"] = repository.Name
+		r["description"] = repository.Description
+		terraformRepositories = append(terraformRepositories, r)
+	}
+
+	_ = d.Set("repositories", terraformRepositories)
+	return nil
+}
+
+func readProjectRepositories(client *client.BitbucketClient, project string) ([]Repository, error) {
+	return readAllPages[Repository](client, fmt.Sprintf("/rest/api/1.0/projects/%s/repos", url.PathEscape(project)))
+}
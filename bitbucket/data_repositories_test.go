@@ -0,0 +1,59 @@
+package bitbucket
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccBitbucketDataRepositories(t *testing.T) {
+	projectKey := fmt.Sprintf("TEST%v", rand.New(rand.NewSource(time.Now().UnixNano())).Int())
+	label := fmt.Sprintf("tier-%v", rand.New(rand.NewSource(time.Now().UnixNano())).Intn(100000))
+
+	config := fmt.Sprintf(`
+		resource "bitbucketserver_project" "test" {
+			key  = "%v"
+			name = "test-project-%v"
+		}
+
+		resource "bitbucketserver_repository" "labelled" {
+			project = bitbucketserver_project.test.key
+			name    = "labelled"
+			labels  = ["%v"]
+		}
+
+		resource "bitbucketserver_repository" "unlabelled" {
+			project = bitbucketserver_project.test.key
+			name    = "unlabelled"
+		}
+
+		data "bitbucketserver_repositories" "project" {
+			project    = bitbucketserver_project.test.key
+			depends_on = [bitbucketserver_repository.labelled, bitbucketserver_repository.unlabelled]
+		}
+
+		data "bitbucketserver_repositories" "label" {
+			label      = "%v"
+			depends_on = [bitbucketserver_repository.labelled]
+		}
+	`, projectKey, projectKey, label, label)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bitbucketserver_repositories.project", "repositories.#", "2"),
+					resource.TestCheckResourceAttr("data.bitbucketserver_repositories.label", "repositories.#", "1"),
+					resource.TestCheckResourceAttr("data.bitbucketserver_repositories.label", "repositories.0.project", projectKey),
+					resource.TestCheckResourceAttr("data.bitbucketserver_repositories.label", "repositories.0.slug", "labelled"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,105 @@
+package bitbucket
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	bitbucketTypes "github.com/xvlcwk-terraform/terraform-provider-bitbucketserver/bitbucket/util/types"
+	"strings"
+	"unicode/utf8"
+)
+
+func dataSourceRepositoryFile() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceRepositoryFileRead,
+
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"repository": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"path": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Path of the file within the repository.",
+			},
+			"ref": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Branch, tag or commit to read the file at, the default branch if omitted.",
+			},
+			"content": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Content of a text file, empty for binary files.",
+			},
+			"content_base64": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"binary": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"size": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"last_commit_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"last_commit_message": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceRepositoryFileRead(d *schema.ResourceData, m interface{}) error {
+	project := d.Get("project").(string)
+	repository := d.Get("repository").(string)
+	path := strings.Trim(d.Get("path").(string), "/")
+	ref := d.Get("ref").(string)
+
+	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+	content, err := readRawRepositoryFile(client, project, repository, path, ref)
+	if err != nil {
+		return err
+	}
+
+	if content == nil {
+		return fmt.Errorf("file %s not found in repository %s/%s at %q", path, project, repository, ref)
+	}
+
+	commit, err := readLatestCommit(client, project, repository, ref, path)
+	if err != nil {
+		return err
+	}
+
+	// NUL bytes are valid UTF-8 but practically only occur in binary files
+	binary := !utf8.Valid(content) || bytes.IndexByte(content, 0) >= 0
+
+	d.SetId(fmt.Sprintf("%s/%s/%s:%s", project, repository, ref, path))
+	_ = d.Set("binary", binary)
+	_ = d.Set("size", len(content))
+	_ = d.Set("content_base64", base64.StdEncoding.EncodeToString(content))
+	if binary {
+		_ = d.Set("content", "")
+	} else {
+		_ = d.Set("content", string(content))
+	}
+
+	if commit != nil {
+		_ = d.Set("last_commit_id", commit.ID)
+		_ = d.Set("last_commit_message", commit.Message)
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package bitbucket
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccBitbucketDataRepositoryFile(t *testing.T) {
+	projectKey := fmt.Sprintf("TEST%v", rand.New(rand.NewSource(time.Now().UnixNano())).Int())
+
+	config := fmt.Sprintf(`
+		resource "bitbucketserver_project" "test" {
+			key  = "%v"
+			name = "test-project-%v"
+		}
+
+		resource "bitbucketserver_repository" "test" {
+			project        = bitbucketserver_project.test.key
+			name           = "repo"
+			default_branch = "main"
+
+			initialize {
+				readme = "# repo"
+			}
+		}
+
+		resource "bitbucketserver_repository_file" "owners" {
+			project        = bitbucketserver_project.test.key
+			repository     = bitbucketserver_repository.test.slug
+			path           = "OWNERS.yaml"
+			content        = "team: platform"
+			commit_message = "Add the owners"
+		}
+
+		data "bitbucketserver_repository_file" "owners" {
+			project    = bitbucketserver_project.test.key
+			repository = bitbucketserver_repository.test.slug
+			path       = "OWNERS.yaml"
+			ref        = "main"
+			depends_on = [bitbucketserver_repository_file.owners]
+		}
+	`, projectKey, projectKey)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bitbucketserver_repository_file.owners", "content", "team: platform"),
+					resource.TestCheckResourceAttr("data.bitbucketserver_repository_file.owners", "content_base64", "dGVhbTogcGxhdGZvcm0="),
+					resource.TestCheckResourceAttr("data.bitbucketserver_repository_file.owners", "binary", "false"),
+					resource.TestCheckResourceAttr("data.bitbucketserver_repository_file.owners", "size", "14"),
+					resource.TestCheckResourceAttr("data.bitbucketserver_repository_file.owners", "last_commit_message", "Add the owners"),
+					resource.TestCheckResourceAttrPair("data.bitbucketserver_repository_file.owners", "last_commit_id", "bitbucketserver_repository_file.owners", "last_commit_id"),
+				),
+			},
+		},
+	})
+}
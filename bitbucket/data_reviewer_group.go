@@ -0,0 +1,69 @@
+package bitbucket
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	bitbucketTypes "github.com/xvlcwk-terraform/terraform-provider-bitbucketserver/bitbucket/util/types"
+)
+
+func dataSourceReviewerGroup() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceReviewerGroupRead,
+
+		Schema: map[string]*schema.Schema{
+			"project_key": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"repository_slug": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"users": {
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+				Computed: true,
+			},
+			"reviewer_group_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceReviewerGroupRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+
+	projectKey := d.Get("project_key").(string)
+	repositorySlug := d.Get("repository_slug").(string)
+	name := d.Get("name").(string)
+
+	reviewerGroups, err := readReviewerGroups(client, projectKey, repositorySlug)
+	if err != nil {
+		return err
+	}
+
+	for _, reviewerGroup := range reviewerGroups {
+		if reviewerGroup.Name != name {
+			continue
+		}
+
+		d.SetId(createResourceID(reviewerGroup.ID, projectKey, repositorySlug))
+		_ = d.Set("reviewer_group_id", reviewerGroup.ID)
+		_ = d.Set("description", reviewerGroup.Description)
+		_ = d.Set("users", collapseReviewerGroupUsers(reviewerGroup.Users))
+		return nil
+	}
+
+	return fmt.Errorf("reviewer group %s not found in %s", name, getReviewerGroupsURI(projectKey, repositorySlug))
+}
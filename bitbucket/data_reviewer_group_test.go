@@ -0,0 +1,44 @@
+package bitbucket
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccBitbucketDataReviewerGroup(t *testing.T) {
+	projectKey := fmt.Sprintf("TEST%v", rand.New(rand.NewSource(time.Now().UnixNano())).Int())
+
+	config := baseConfigForRepositoryBasedTests(projectKey) + `
+		resource "bitbucketserver_reviewer_group" "test" {
+			project_key     = bitbucketserver_project.test.key
+			repository_slug = bitbucketserver_repository.test.slug
+			name            = "reviewers"
+			users           = ["admin"]
+		}
+
+		data "bitbucketserver_reviewer_group" "test" {
+			project_key     = bitbucketserver_reviewer_group.test.project_key
+			repository_slug = bitbucketserver_reviewer_group.test.repository_slug
+			name            = bitbucketserver_reviewer_group.test.name
+		}
+	`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.bitbucketserver_reviewer_group.test", "reviewer_group_id", "bitbucketserver_reviewer_group.test", "reviewer_group_id"),
+					resource.TestCheckResourceAttr("data.bitbucketserver_reviewer_group.test", "users.#", "1"),
+					resource.TestCheckTypeSetElemAttr("data.bitbucketserver_reviewer_group.test", "users.*", "admin"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,96 @@
+package bitbucket
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	bitbucketTypes "github.com/xvlcwk-terraform/terraform-provider-bitbucketserver/bitbucket/util/types"
+	"net/url"
+)
+
+func dataSourceTags() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceTagsRead,
+
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"repository": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"filter_text": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return tags containing this text.",
+			},
+			"order_by": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "MODIFICATION",
+				ValidateFunc: validation.StringInSlice([]string{"ALPHABETICAL", "MODIFICATION"}, false),
+			},
+			"tags": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"display_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"latest_commit": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"hash": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTagsRead(d *schema.ResourceData, m interface{}) error {
+	project := d.Get("project").(string)
+	repository := d.Get("repository").(string)
+	filterText := d.Get("filter_text").(string)
+
+	resourceURL := fmt.Sprintf("%s?orderBy=%s",
+		getRepositoryRefsURI(project, repository, "tags"),
+		url.QueryEscape(d.Get("order_by").(string)),
+	)
+	if filterText != "" {
+		resourceURL += "&filterText=" + url.QueryEscape(filterText)
+	}
+
+	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+	tags, err := readAllPages[GitRef](client, resourceURL)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", project, repository))
+
+	var terraformTags []interface{}
+	for _, tag := range tags {
+		t := make(map[string]interface{})
+		t["id"] = tag.ID
+		t["display_id"] = tag.DisplayID
+		t["latest_commit"] = tag.LatestCommit
+		t["hash"] = tag.Hash
+		terraformTags = append(terraformTags, t)
+	}
+
+	_ = d.Set("tags", terraformTags)
+	return nil
+}
@@ -0,0 +1,60 @@
+package bitbucket
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccBitbucketDataTags(t *testing.T) {
+	projectKey := fmt.Sprintf("TEST%v", rand.New(rand.NewSource(time.Now().UnixNano())).Int())
+
+	config := fmt.Sprintf(`
+		resource "bitbucketserver_project" "test" {
+			key  = "%v"
+			name = "test-project-%v"
+		}
+
+		resource "bitbucketserver_repository" "test" {
+			project        = bitbucketserver_project.test.key
+			name           = "repo"
+			default_branch = "main"
+
+			initialize {
+				readme = "# repo"
+			}
+		}
+
+		resource "bitbucketserver_tag" "release" {
+			project     = bitbucketserver_project.test.key
+			repository  = bitbucketserver_repository.test.slug
+			name        = "v1.0.0"
+			start_point = "main"
+		}
+
+		data "bitbucketserver_tags" "test" {
+			project     = bitbucketserver_project.test.key
+			repository  = bitbucketserver_repository.test.slug
+			filter_text = "v1."
+			depends_on  = [bitbucketserver_tag.release]
+		}
+	`, projectKey, projectKey)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bitbucketserver_tags.test", "tags.#", "1"),
+					resource.TestCheckResourceAttr("data.bitbucketserver_tags.test", "tags.0.display_id", "v1.0.0"),
+					resource.TestCheckResourceAttr("data.bitbucketserver_tags.test", "tags.0.id", "refs/tags/v1.0.0"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,74 @@
+package bitbucket
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	bitbucketTypes "github.com/xvlcwk-terraform/terraform-provider-bitbucketserver/bitbucket/util/types"
+	"strings"
+)
+
+func dataSourceUserGPGKeys() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceUserGPGKeysRead,
+
+		Schema: map[string]*schema.Schema{
+			"user": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"keys": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"fingerprint": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"email_address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"expiry_date": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceUserGPGKeysRead(d *schema.ResourceData, m interface{}) error {
+	user := d.Get("user").(string)
+	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+
+	keys, err := readUserGPGKeys(client, user)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(user)
+
+	var terraformKeys []interface{}
+	for _, key := range keys {
+		k := make(map[string]interface{})
+		k["key_id"] = key.ID
+		k["key"] = strings.TrimSpace(key.Text)
+		k["fingerprint"] = key.Fingerprint
+		k["email_address"] = key.EmailAddress
+		k["expiry_date"] = formatGPGKeyExpiry(key.ExpiryDate)
+		terraformKeys = append(terraformKeys, k)
+	}
+
+	_ = d.Set("keys", terraformKeys)
+	return nil
+}
@@ -0,0 +1,83 @@
+package bitbucket
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	bitbucketTypes "github.com/xvlcwk-terraform/terraform-provider-bitbucketserver/bitbucket/util/types"
+)
+
+func dataSourceUserSSHKeys() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceUserSSHKeysRead,
+
+		Schema: map[string]*schema.Schema{
+			"user": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"keys": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"label": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"fingerprint": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"algorithm": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"bit_length": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"expiry_days": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceUserSSHKeysRead(d *schema.ResourceData, m interface{}) error {
+	user := d.Get("user").(string)
+	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+
+	keys, err := readUserSSHKeys(client, user)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(user)
+
+	var terraformKeys []interface{}
+	for _, key := range keys {
+		k := make(map[string]interface{})
+		k["key_id"] = key.ID
+		k["label"] = key.Label
+		k["key"] = key.Text
+		k["fingerprint"] = key.Fingerprint
+		k["algorithm"] = key.AlgorithmType
+		k["bit_length"] = key.BitLength
+		k["expiry_days"] = key.ExpiryDays
+		terraformKeys = append(terraformKeys, k)
+	}
+
+	_ = d.Set("keys", terraformKeys)
+	return nil
+}
@@ -0,0 +1,81 @@
+package bitbucket
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceWebhooks() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceWebhooksRead,
+
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"repository": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"webhooks": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"webhook_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"webhook_url": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"events": {
+							Type:     schema.TypeList,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Computed: true,
+						},
+						"active": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceWebhooksRead(d *schema.ResourceData, m interface{}) error {
+	project := d.Get("project").(string)
+	repository := d.Get("repository").(string)
+
+	webhooks, err := readWebhooks(m, getWebhooksURI(project, repository))
+	if err != nil {
+		return err
+	}
+
+	if repository == "" {
+		d.SetId(project)
+	} else {
+		d.SetId(project + "/" + repository)
+	}
+
+	var terraformWebhooks []interface{}
+	for _, webhook := range webhooks {
+		w := make(map[string]interface{})
+		w["webhook_id"] = webhook.ID
+		w["name"] = webhook.Name
+		w["webhook_url"] = webhook.URL
+		w["events"] = collapseWebhookEvents(webhook.Events)
+		w["active"] = webhook.Active
+		terraformWebhooks = append(terraformWebhooks, w)
+	}
+
+	_ = d.Set("webhooks", terraformWebhooks)
+	return nil
+}
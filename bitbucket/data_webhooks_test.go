@@ -0,0 +1,57 @@
+package bitbucket
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccBitbucketDataWebhooks(t *testing.T) {
+	projectKey := fmt.Sprintf("TEST%v", rand.New(rand.NewSource(time.Now().UnixNano())).Int())
+
+	config := baseConfigForRepositoryBasedTests(projectKey) + `
+		resource "bitbucketserver_project_webhook" "test" {
+			project     = bitbucketserver_project.test.key
+			name        = "project-hook"
+			webhook_url = "https://www.google.com/"
+			events      = ["repo:refs_changed"]
+		}
+
+		resource "bitbucketserver_repository_webhook" "test" {
+			project     = bitbucketserver_project.test.key
+			repository  = bitbucketserver_repository.test.slug
+			name        = "repository-hook"
+			webhook_url = "https://www.google.com/"
+			events      = ["pr:merged"]
+		}
+
+		data "bitbucketserver_webhooks" "project" {
+			project    = bitbucketserver_project_webhook.test.project
+		}
+
+		data "bitbucketserver_webhooks" "repository" {
+			project    = bitbucketserver_repository_webhook.test.project
+			repository = bitbucketserver_repository_webhook.test.repository
+		}
+	`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bitbucketserver_webhooks.project", "webhooks.#", "1"),
+					resource.TestCheckResourceAttr("data.bitbucketserver_webhooks.project", "webhooks.0.name", "project-hook"),
+					resource.TestCheckResourceAttr("data.bitbucketserver_webhooks.repository", "webhooks.#", "1"),
+					resource.TestCheckResourceAttr("data.bitbucketserver_webhooks.repository", "webhooks.0.name", "repository-hook"),
+					resource.TestCheckResourceAttr("data.bitbucketserver_webhooks.repository", "webhooks.0.events.0", "pr:merged"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,80 @@
+package bitbucket
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/xvlcwk-terraform/terraform-provider-bitbucketserver/bitbucket/util/client"
+	"net/url"
+	"strings"
+)
+
+type GitRef struct {
+	ID           string `json:"id,omitempty"`
+	DisplayID    string `json:"displayId,omitempty"`
+	Type         string `json:"type,omitempty"`
+	LatestCommit string `json:"latestCommit,omitempty"`
+	Hash         string `json:"hash,omitempty"`
+	IsDefault    bool   `json:"isDefault,omitempty"`
+	// plugin provided details, only returned when requested
+	Metadata map[string]json.RawMessage `json:"metadata,omitempty"`
+}
+
+type GitRefAheadBehind struct {
+	Ahead  int `json:"ahead"`
+	Behind int `json:"behind"`
+}
+
+const gitRefAheadBehindMetadataKey = "com.atlassian.bitbucket.server.bitbucket-branch:ahead-behind-metadata-provider"
+
+type GitRefCreateRequest struct {
+	Name       string `json:"name"`
+	StartPoint string `json:"startPoint"`
+	Message    string `json:"message,omitempty"`
+}
+
+func getRepositoryRefsURI(project string, repoSlug string, refType string) string {
+	return fmt.Sprintf("/rest/api/latest/projects/%s/repos/%s/%s",
+		url.PathEscape(project),
+		url.PathEscape(repoSlug),
+		refType,
+	)
+}
+
+// parseGitRefID splits the `project/repository/name` ID of branches and tags, the name itself may contain slashes.
+func parseGitRefID(id string) (project string, repoSlug string, name string, err error) {
+	parts := strings.SplitN(id, "/", 3)
+	if len(parts) != 3 || parts[2] == "" {
+		return "", "", "", fmt.Errorf("incorrect ID format, should match `project/repository/name`")
+	}
+
+	return parts[0], parts[1], parts[2], nil
+}
+
+// aheadBehind returns the number of commits the ref is ahead and behind of the base, zero if the details weren't requested.
+func (r GitRef) aheadBehind() GitRefAheadBehind {
+	var aheadBehind GitRefAheadBehind
+	if metadata, ok := r.Metadata[gitRefAheadBehindMetadataKey]; ok {
+		_ = json.Unmarshal(metadata, &aheadBehind)
+	}
+
+	return aheadBehind
+}
+
+// readBranch returns nil if the branch doesn't exist.
+func readBranch(client *client.BitbucketClient, project string, repoSlug string, name string) (*GitRef, error) {
+	branches, err := readAllPages[GitRef](client, fmt.Sprintf("%s?filterText=%s&boostMatches=true",
+		getRepositoryRefsURI(project, repoSlug, "branches"),
+		url.QueryEscape(name),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, branch := range branches {
+		if branch.DisplayID == name {
+			return &branch, nil
+		}
+	}
+
+	return nil, nil
+}
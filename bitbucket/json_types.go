@@ -8,6 +8,9 @@ import (
 
 type jsonTime time.Time
 
+// jsonTimeLayout is the format of jsonTime.String, used to parse times stored in the state.
+const jsonTimeLayout = "2006-01-02 15:04:05 -0700 MST"
+
 func (t jsonTime) MarshalJSON() ([]byte, error) {
 	return []byte(strconv.FormatInt(time.Time(t).Unix(), 10)), nil
 }
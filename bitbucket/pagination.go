@@ -0,0 +1,59 @@
+package bitbucket
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/xvlcwk-terraform/terraform-provider-bitbucketserver/bitbucket/util/client"
+	"strings"
+)
+
+// PaginatedResponse is a single page of a Bitbucket listing.
+type PaginatedResponse[T any] struct {
+	Values        []T  `json:"values,omitempty"`
+	Size          int  `json:"size,omitempty"`
+	Limit         int  `json:"limit,omitempty"`
+	IsLastPage    bool `json:"isLastPage,omitempty"`
+	Start         int  `json:"start,omitempty"`
+	NextPageStart int  `json:"nextPageStart,omitempty"`
+}
+
+// readAllPages reads all pages of a listing, resourceURL may already contain query parameters.
+func readAllPages[T any](client *client.BitbucketClient, resourceURL string) ([]T, error) {
+	separator := "?"
+	if strings.Contains(resourceURL, "?") {
+		separator = "&"
+	}
+
+	pageURL := resourceURL
+
+	var values []T
+
+	for {
+		resp, err := client.Get(pageURL)
+		if err != nil {
+			return nil, err
+		}
+
+		var page PaginatedResponse[T]
+
+		decoder := json.NewDecoder(resp.Body)
+		err = decoder.Decode(&page)
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, page.Values...)
+
+		if page.IsLastPage {
+			break
+		}
+
+		pageURL = fmt.Sprintf("%s%sstart=%d",
+			resourceURL,
+			separator,
+			page.NextPageStart,
+		)
+	}
+
+	return values, nil
+}
@@ -47,7 +47,9 @@ func Provider() *schema.Provider {
 		ConfigureContextFunc: providerConfigure,
 		DataSourcesMap: map[string]*schema.Resource{
 			"bitbucketserver_application_properties":        dataSourceApplicationProperties(),
+			"bitbucketserver_branches":                      dataSourceBranches(),
 			"bitbucketserver_cluster":                       dataSourceCluster(),
+			"bitbucketserver_commit":                        dataSourceCommit(),
 			"bitbucketserver_global_permissions_groups":     dataSourceGlobalPermissionsGroups(),
 			"bitbucketserver_global_permissions_users":      dataSourceGlobalPermissionsUsers(),
 			"bitbucketserver_groups":                        dataSourceGroups(),
@@ -56,35 +58,54 @@ func Provider() *schema.Provider {
 			"bitbucketserver_project_hooks":                 dataSourceProjectHooks(),
 			"bitbucketserver_project_permissions_groups":    dataSourceProjectPermissionsGroups(),
 			"bitbucketserver_project_permissions_users":     dataSourceProjectPermissionsUsers(),
+			"bitbucketserver_repositories":                  dataSourceRepositories(),
+			"bitbucketserver_repository_file":               dataSourceRepositoryFile(),
 			"bitbucketserver_repository_hooks":              dataSourceRepositoryHooks(),
 			"bitbucketserver_repository_permissions_groups": dataSourceRepositoryPermissionsGroups(),
 			"bitbucketserver_repository_permissions_users":  dataSourceRepositoryPermissionsUsers(),
+			"bitbucketserver_reviewer_group":                dataSourceReviewerGroup(),
+			"bitbucketserver_tags":                          dataSourceTags(),
 			"bitbucketserver_user":                          dataSourceUser(),
+			"bitbucketserver_user_gpg_keys":                 dataSourceUserGPGKeys(),
+			"bitbucketserver_user_ssh_keys":                 dataSourceUserSSHKeys(),
+			"bitbucketserver_webhooks":                      dataSourceWebhooks(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			"bitbucketserver_banner":                       resourceBanner(),
+			"bitbucketserver_branch":                       resourceBranch(),
+			"bitbucketserver_branching_model":              resourceBranchingModel(),
 			"bitbucketserver_default_reviewers_condition":  resourceDefaultReviewersCondition(),
 			"bitbucketserver_global_permissions_group":     resourceGlobalPermissionsGroup(),
 			"bitbucketserver_global_permissions_user":      resourceGlobalPermissionsUser(),
 			"bitbucketserver_group":                        resourceGroup(),
+			"bitbucketserver_group_members":                resourceGroupMembers(),
 			"bitbucketserver_license":                      resourceLicense(),
 			"bitbucketserver_mail_server":                  resourceMailServer(),
 			"bitbucketserver_plugin":                       resourcePlugin(),
 			"bitbucketserver_plugin_config":                resourcePluginConfig(),
 			"bitbucketserver_project":                      resourceProject(),
+			"bitbucketserver_project_access_key":           resourceProjectAccessKey(),
 			"bitbucketserver_project_branch_permissions":   resourceBranchPermissions(),
 			"bitbucketserver_project_hook":                 resourceProjectHook(),
 			"bitbucketserver_project_permissions_group":    resourceProjectPermissionsGroup(),
 			"bitbucketserver_project_permissions_user":     resourceProjectPermissionsUser(),
+			"bitbucketserver_project_webhook":              resourceProjectWebhook(),
+			"bitbucketserver_pull_request_settings":        resourcePullRequestSettings(),
 			"bitbucketserver_repository":                   resourceRepository(),
 			"bitbucketserver_repository_deploy_key":        resourceRepositoryDeployKey(),
+			"bitbucketserver_repository_file":              resourceRepositoryFile(),
 			"bitbucketserver_repository_hook":              resourceRepositoryHook(),
 			"bitbucketserver_repository_permissions_group": resourceRepositoryPermissionsGroup(),
 			"bitbucketserver_repository_permissions_user":  resourceRepositoryPermissionsUser(),
 			"bitbucketserver_repository_webhook":           resourceRepositoryWebhook(),
+			"bitbucketserver_required_builds_condition":    resourceRequiredBuildsCondition(),
+			"bitbucketserver_reviewer_group":               resourceReviewerGroup(),
+			"bitbucketserver_tag":                          resourceTag(),
 			"bitbucketserver_user":                         resourceUser(),
 			"bitbucketserver_user_access_token":            resourceUserAccessToken(),
+			"bitbucketserver_user_gpg_key":                 resourceUserGPGKey(),
 			"bitbucketserver_user_group":                   resourceUserGroup(),
+			"bitbucketserver_user_ssh_key":                 resourceUserSSHKey(),
 		},
 	}
 }
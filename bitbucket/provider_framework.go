@@ -39,6 +39,7 @@ type BitbucketServerProviderModel struct {
 
 func (p *BitbucketServerProviderFramework) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
+		newProjectAccessTokenResource,
 		newRepositoryAccessTokenResource,
 	}
 }
@@ -0,0 +1,126 @@
+package bitbucket
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/xvlcwk-terraform/terraform-provider-bitbucketserver/bitbucket/util/client"
+	"io/ioutil"
+	"net/url"
+	"strings"
+)
+
+type CommitAuthor struct {
+	Name         string `json:"name,omitempty"`
+	EmailAddress string `json:"emailAddress,omitempty"`
+}
+
+type Commit struct {
+	ID              string       `json:"id,omitempty"`
+	DisplayID       string       `json:"displayId,omitempty"`
+	Message         string       `json:"message,omitempty"`
+	Author          CommitAuthor `json:"author,omitempty"`
+	AuthorTimestamp int64        `json:"authorTimestamp,omitempty"`
+}
+
+// escapeFilePath escapes each segment of a path within a repository, keeping the slashes between them.
+func escapeFilePath(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// commitRepositoryFile creates or updates a single file on the branch. sourceCommitID has to be the
+// last commit known to contain the file when updating it, Bitbucket rejects the edit with a conflict otherwise.
+func commitRepositoryFile(client *client.BitbucketClient, project string, repoSlug string, path string, content string, branch string, message string, sourceCommitID string) (*Commit, error) {
+	fields := map[string]string{
+		"content": content,
+		"branch":  branch,
+		"message": message,
+	}
+	if sourceCommitID != "" {
+		fields["sourceCommitId"] = sourceCommitID
+	}
+
+	resp, err := client.PutMultipartForm(fmt.Sprintf("/rest/api/latest/projects/%s/repos/%s/browse/%s",
+		url.PathEscape(project),
+		url.PathEscape(repoSlug),
+		escapeFilePath(path),
+	), fields)
+	if err != nil {
+		return nil, err
+	}
+
+	var commit Commit
+
+	decoder := json.NewDecoder(resp.Body)
+	err = decoder.Decode(&commit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &commit, nil
+}
+
+// readRawRepositoryFile returns nil content if the file doesn't exist at the given ref, the default branch if at is empty.
+func readRawRepositoryFile(client *client.BitbucketClient, project string, repoSlug string, path string, at string) ([]byte, error) {
+	resourceURL := fmt.Sprintf("/rest/api/latest/projects/%s/repos/%s/raw/%s",
+		url.PathEscape(project),
+		url.PathEscape(repoSlug),
+		escapeFilePath(path),
+	)
+	if at != "" {
+		resourceURL += "?at=" + url.QueryEscape(at)
+	}
+
+	resp, err := client.Get(resourceURL)
+	if resp != nil && resp.StatusCode == 404 {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ioutil.ReadAll(resp.Body)
+}
+
+// readLatestCommit returns the latest commit reachable from the ref, the default branch if at is empty.
+// With a path, the latest commit modifying that path is returned. nil if there is none.
+func readLatestCommit(client *client.BitbucketClient, project string, repoSlug string, at string, path string) (*Commit, error) {
+	resourceURL := fmt.Sprintf("/rest/api/latest/projects/%s/repos/%s/commits?limit=1",
+		url.PathEscape(project),
+		url.PathEscape(repoSlug),
+	)
+	if at != "" {
+		resourceURL += "&until=" + url.QueryEscape(at)
+	}
+	if path != "" {
+		resourceURL += "&path=" + url.QueryEscape(strings.Trim(path, "/"))
+	}
+
+	resp, err := client.Get(resourceURL)
+	if resp != nil && resp.StatusCode == 404 {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var paginatedCommits PaginatedResponse[Commit]
+
+	decoder := json.NewDecoder(resp.Body)
+	err = decoder.Decode(&paginatedCommits)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(paginatedCommits.Values) == 0 {
+		return nil, nil
+	}
+
+	return &paginatedCommits.Values[0], nil
+}
@@ -0,0 +1,166 @@
+package bitbucket
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	bitbucketTypes "github.com/xvlcwk-terraform/terraform-provider-bitbucketserver/bitbucket/util/types"
+	"log"
+	"net/url"
+)
+
+type BranchDeleteRequest struct {
+	Name   string `json:"name"`
+	DryRun bool   `json:"dryRun"`
+}
+
+func resourceBranch() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceBranchCreate,
+		Read:   resourceBranchRead,
+		Update: resourceBranchUpdate,
+		Delete: resourceBranchDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceBranchImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"repository": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the branch, without the refs/heads/ prefix.",
+			},
+			"start_point": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Branch, tag or commit the branch is created from. Only used when the branch is created.",
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return d.Id() != ""
+				},
+			},
+			"deletion_protection": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Refuse to delete the branch when the resource is destroyed.",
+			},
+			"latest_commit": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"is_default": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func getBranchUtilsURI(project string, repoSlug string) string {
+	return fmt.Sprintf("/rest/branch-utils/latest/projects/%s/repos/%s/branches",
+		url.PathEscape(project),
+		url.PathEscape(repoSlug),
+	)
+}
+
+func resourceBranchCreate(d *schema.ResourceData, m interface{}) error {
+	project := d.Get("project").(string)
+	repository := d.Get("repository").(string)
+	name := d.Get("name").(string)
+
+	request, err := json.Marshal(&GitRefCreateRequest{
+		Name:       name,
+		StartPoint: d.Get("start_point").(string),
+	})
+	if err != nil {
+		return err
+	}
+
+	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+	_, err = client.Post(getBranchUtilsURI(project, repository), bytes.NewBuffer(request))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", project, repository, name))
+
+	return resourceBranchRead(d, m)
+}
+
+func resourceBranchUpdate(d *schema.ResourceData, m interface{}) error {
+	// every other argument forces a new branch, deletion_protection is only checked on delete
+	return resourceBranchRead(d, m)
+}
+
+func resourceBranchRead(d *schema.ResourceData, m interface{}) error {
+	project, repository, name, err := parseGitRefID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+	branch, err := readBranch(client, project, repository, name)
+	if err != nil {
+		return err
+	}
+
+	if branch == nil {
+		log.Printf("[WARN] Branch %s not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	_ = d.Set("project", project)
+	_ = d.Set("repository", repository)
+	_ = d.Set("name", branch.DisplayID)
+	_ = d.Set("latest_commit", branch.LatestCommit)
+	_ = d.Set("is_default", branch.IsDefault)
+
+	return nil
+}
+
+func resourceBranchImport(d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	// the protection isn't known to Bitbucket, an imported branch starts unprotected
+	_ = d.Set("deletion_protection", false)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceBranchDelete(d *schema.ResourceData, m interface{}) error {
+	if d.Get("deletion_protection").(bool) {
+		return fmt.Errorf("branch %s is protected from deletion, set deletion_protection = false and apply before destroying it", d.Id())
+	}
+
+	project, repository, name, err := parseGitRefID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	request, err := json.Marshal(&BranchDeleteRequest{
+		Name:   "refs/heads/" + name,
+		DryRun: false,
+	})
+	if err != nil {
+		return err
+	}
+
+	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+	resp, err := client.Do("DELETE", getBranchUtilsURI(project, repository), bytes.NewBuffer(request), "application/json")
+	if resp != nil && resp.StatusCode == 404 {
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,71 @@
+package bitbucket
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccBitbucketResourceBranch(t *testing.T) {
+	projectKey := fmt.Sprintf("TEST%v", rand.New(rand.NewSource(time.Now().UnixNano())).Int())
+
+	config := fmt.Sprintf(`
+		resource "bitbucketserver_project" "test" {
+			key  = "%v"
+			name = "test-project-%v"
+		}
+
+		resource "bitbucketserver_repository" "test" {
+			project        = bitbucketserver_project.test.key
+			name           = "repo"
+			default_branch = "main"
+
+			initialize {
+				readme = "# repo"
+			}
+		}
+
+		resource "bitbucketserver_branch" "test" {
+			project     = bitbucketserver_project.test.key
+			repository  = bitbucketserver_repository.test.slug
+			name        = "release/1.0"
+			start_point = "main"
+			deletion_protection = true
+		}
+	`, projectKey, projectKey)
+
+	configUnprotected := strings.ReplaceAll(config, "deletion_protection = true", "deletion_protection = false")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucketserver_branch.test", "id", projectKey+"/repo/release/1.0"),
+					resource.TestCheckResourceAttr("bitbucketserver_branch.test", "name", "release/1.0"),
+					resource.TestCheckResourceAttrSet("bitbucketserver_branch.test", "latest_commit"),
+					resource.TestCheckResourceAttr("bitbucketserver_branch.test", "is_default", "false"),
+				),
+			},
+			{
+				ResourceName:            "bitbucketserver_branch.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"start_point", "deletion_protection"},
+			},
+			{
+				// the protection has to be lifted before the branch can be destroyed
+				Config: configUnprotected,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucketserver_branch.test", "deletion_protection", "false"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,295 @@
+package bitbucket
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	bitbucketTypes "github.com/xvlcwk-terraform/terraform-provider-bitbucketserver/bitbucket/util/types"
+	"log"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+type BranchingModelBranch struct {
+	RefId      string `json:"refId,omitempty"`
+	UseDefault bool   `json:"useDefault"`
+}
+
+type BranchingModelType struct {
+	Id          string `json:"id"`
+	DisplayName string `json:"displayName,omitempty"`
+	Enabled     bool   `json:"enabled"`
+	Prefix      string `json:"prefix,omitempty"`
+}
+
+type BranchingModelConfiguration struct {
+	Development BranchingModelBranch `json:"development"`
+	Production  BranchingModelBranch `json:"production"`
+	Types       []BranchingModelType `json:"types,omitempty"`
+	Scope       *struct {
+		Type       string `json:"type,omitempty"`
+		ResourceId int    `json:"resourceId,omitempty"`
+	} `json:"scope,omitempty"`
+}
+
+var branchingModelTypeNames = map[string]string{
+	"BUGFIX":  "Bugfix",
+	"FEATURE": "Feature",
+	"HOTFIX":  "Hotfix",
+	"RELEASE": "Release",
+}
+
+func branchingModelTypeIds() []string {
+	ids := make([]string, 0, len(branchingModelTypeNames))
+	for id := range branchingModelTypeNames {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return ids
+}
+
+func resourceBranchingModel() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceBranchingModelCreate,
+		Read:   resourceBranchingModelRead,
+		Update: resourceBranchingModelCreate,
+		Delete: resourceBranchingModelDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"repository": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Repository slug. If empty, the branching model of the project is managed.",
+			},
+			"inherit": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				RequiredWith:  []string{"repository"},
+				ConflictsWith: []string{"development_branch", "production_branch", "branch_type"},
+				Description:   "Whether the repository inherits the branching model of its project.",
+			},
+			"development_branch": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The development branch, e.g. refs/heads/develop. If empty, the default branch of the repository is used.",
+			},
+			"production_branch": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The production branch, e.g. refs/heads/main. If empty, production is disabled.",
+			},
+			"branch_type": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The enabled branch types, types not listed are disabled.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"BUGFIX", "FEATURE", "HOTFIX", "RELEASE"}, false),
+						},
+						"enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+						"prefix": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func getBranchingModelURI(project string, repository string) string {
+	if repository == "" {
+		return fmt.Sprintf("/rest/branch-utils/latest/projects/%s/branchmodel/configuration",
+			url.PathEscape(project),
+		)
+	}
+
+	return fmt.Sprintf("/rest/branch-utils/latest/projects/%s/repos/%s/branchmodel/configuration",
+		url.PathEscape(project),
+		url.PathEscape(repository),
+	)
+}
+
+func newBranchingModelFromResource(d *schema.ResourceData) *BranchingModelConfiguration {
+	development := d.Get("development_branch").(string)
+	production := d.Get("production_branch").(string)
+
+	configuration := &BranchingModelConfiguration{
+		Development: BranchingModelBranch{
+			RefId:      development,
+			UseDefault: development == "",
+		},
+		Production: BranchingModelBranch{
+			RefId:      production,
+			UseDefault: false,
+		},
+	}
+
+	configured := make(map[string]map[string]interface{})
+	for _, item := range d.Get("branch_type").(*schema.Set).List() {
+		branchType := item.(map[string]interface{})
+		configured[branchType["id"].(string)] = branchType
+	}
+
+	// every type is sent, the ones missing in the configuration are disabled
+	for _, id := range branchingModelTypeIds() {
+		branchType := BranchingModelType{
+			Id:          id,
+			DisplayName: branchingModelTypeNames[id],
+		}
+
+		if item, ok := configured[id]; ok {
+			branchType.Enabled = item["enabled"].(bool)
+			branchType.Prefix = item["prefix"].(string)
+		}
+
+		configuration.Types = append(configuration.Types, branchType)
+	}
+
+	return configuration
+}
+
+func resourceBranchingModelCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+
+	project := d.Get("project").(string)
+	repository := d.Get("repository").(string)
+
+	if d.Get("inherit").(bool) {
+		resp, err := client.Delete(getBranchingModelURI(project, repository))
+		if err != nil && (resp == nil || resp.StatusCode != 404) {
+			return err
+		}
+	} else {
+		bytedata, err := json.Marshal(newBranchingModelFromResource(d))
+		if err != nil {
+			return err
+		}
+
+		_, err = client.Put(getBranchingModelURI(project, repository), bytes.NewBuffer(bytedata))
+		if err != nil {
+			return err
+		}
+	}
+
+	if repository == "" {
+		d.SetId(project)
+	} else {
+		d.SetId(fmt.Sprintf("%s/%s", project, repository))
+	}
+
+	return resourceBranchingModelRead(d, m)
+}
+
+func resourceBranchingModelRead(d *schema.ResourceData, m interface{}) error {
+	id := d.Id()
+	if id != "" {
+		parts := strings.Split(id, "/")
+		if len(parts) == 1 {
+			_ = d.Set("project", parts[0])
+		} else if len(parts) == 2 {
+			_ = d.Set("project", parts[0])
+			_ = d.Set("repository", parts[1])
+		} else {
+			return fmt.Errorf("incorrect ID format, should match `project` or `project/repository`")
+		}
+	}
+
+	project := d.Get("project").(string)
+	repository := d.Get("repository").(string)
+
+	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+	resp, err := client.Get(getBranchingModelURI(project, repository))
+
+	if resp != nil && resp.StatusCode == 404 {
+		log.Printf("[WARN] Branching model %s not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	var configuration BranchingModelConfiguration
+
+	decoder := json.NewDecoder(resp.Body)
+	err = decoder.Decode(&configuration)
+	if err != nil {
+		return err
+	}
+
+	inherited := repository != "" && configuration.Scope != nil && configuration.Scope.Type != "REPOSITORY"
+	_ = d.Set("inherit", inherited)
+
+	if inherited {
+		// the values belong to the project, they are managed there
+		_ = d.Set("development_branch", "")
+		_ = d.Set("production_branch", "")
+		_ = d.Set("branch_type", nil)
+		return nil
+	}
+
+	if configuration.Development.UseDefault {
+		_ = d.Set("development_branch", "")
+	} else {
+		_ = d.Set("development_branch", configuration.Development.RefId)
+	}
+	_ = d.Set("production_branch", configuration.Production.RefId)
+
+	configured := make(map[string]bool)
+	for _, item := range d.Get("branch_type").(*schema.Set).List() {
+		configured[item.(map[string]interface{})["id"].(string)] = true
+	}
+
+	// disabled types are only kept when they are explicitly configured, otherwise they are absent
+	branchTypes := make([]interface{}, 0, len(configuration.Types))
+	for _, branchType := range configuration.Types {
+		if !branchType.Enabled && !configured[branchType.Id] {
+			continue
+		}
+
+		branchTypes = append(branchTypes, map[string]interface{}{
+			"id":      branchType.Id,
+			"enabled": branchType.Enabled,
+			"prefix":  branchType.Prefix,
+		})
+	}
+	_ = d.Set("branch_type", branchTypes)
+
+	return nil
+}
+
+func resourceBranchingModelDelete(d *schema.ResourceData, m interface{}) error {
+	if d.Get("inherit").(bool) {
+		// nothing to remove, the repository already uses the branching model of the project
+		return nil
+	}
+
+	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+	_, err := client.Delete(getBranchingModelURI(d.Get("project").(string), d.Get("repository").(string)))
+
+	return err
+}
@@ -0,0 +1,122 @@
+package bitbucket
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccBitbucketResourceBranchingModel_project(t *testing.T) {
+	projectKey := fmt.Sprintf("TEST%v", rand.New(rand.NewSource(time.Now().UnixNano())).Int())
+
+	config := fmt.Sprintf(`
+		resource "bitbucketserver_project" "test" {
+			key  = "%v"
+			name = "test-project-%v"
+		}
+
+		resource "bitbucketserver_branching_model" "test" {
+			project            = bitbucketserver_project.test.key
+			development_branch = "refs/heads/develop"
+			production_branch  = "refs/heads/main"
+
+			branch_type {
+				id     = "FEATURE"
+				prefix = "feat/"
+			}
+
+			branch_type {
+				id      = "BUGFIX"
+				enabled = false
+				prefix  = "bugfix/"
+			}
+
+			branch_type {
+				id     = "HOTFIX"
+				prefix = "hotfix/"
+			}
+
+			branch_type {
+				id     = "RELEASE"
+				prefix = "release/"
+			}
+		}
+	`, projectKey, projectKey)
+
+	configWithoutHotfix := strings.Replace(config, `
+			branch_type {
+				id     = "HOTFIX"
+				prefix = "hotfix/"
+			}
+`, "", 1)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucketserver_branching_model.test", "id", projectKey),
+					resource.TestCheckResourceAttr("bitbucketserver_branching_model.test", "development_branch", "refs/heads/develop"),
+					resource.TestCheckResourceAttr("bitbucketserver_branching_model.test", "production_branch", "refs/heads/main"),
+					resource.TestCheckResourceAttr("bitbucketserver_branching_model.test", "branch_type.#", "4"),
+					resource.TestCheckTypeSetElemNestedAttrs("bitbucketserver_branching_model.test", "branch_type.*", map[string]string{
+						"id":      "FEATURE",
+						"enabled": "true",
+						"prefix":  "feat/",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("bitbucketserver_branching_model.test", "branch_type.*", map[string]string{
+						"id":      "BUGFIX",
+						"enabled": "false",
+						"prefix":  "bugfix/",
+					}),
+				),
+			},
+			{
+				ResourceName:      "bitbucketserver_branching_model.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// disabled types are only read back when they are configured
+				ImportStateVerifyIgnore: []string{"branch_type"},
+			},
+			{
+				// removing a type disables it
+				Config: configWithoutHotfix,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucketserver_branching_model.test", "branch_type.#", "3"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccBitbucketResourceBranchingModel_repositoryInherit(t *testing.T) {
+	projectKey := fmt.Sprintf("TEST%v", rand.New(rand.NewSource(time.Now().UnixNano())).Int())
+
+	config := baseConfigForRepositoryBasedTests(projectKey) + `
+		resource "bitbucketserver_branching_model" "test" {
+			project    = bitbucketserver_project.test.key
+			repository = bitbucketserver_repository.test.slug
+			inherit    = true
+		}
+	`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucketserver_branching_model.test", "id", fmt.Sprintf("%v/repo", projectKey)),
+					resource.TestCheckResourceAttr("bitbucketserver_branching_model.test", "inherit", "true"),
+				),
+			},
+		},
+	})
+}
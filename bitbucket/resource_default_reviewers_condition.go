@@ -2,11 +2,15 @@ package bitbucket
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	bitbucketClient "github.com/xvlcwk-terraform/terraform-provider-bitbucketserver/bitbucket/util/client"
 	bitbucketTypes "github.com/xvlcwk-terraform/terraform-provider-bitbucketserver/bitbucket/util/types"
 	"io/ioutil"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -56,10 +60,12 @@ var validMatcherTypeIDs = []string{
 
 func resourceDefaultReviewersCondition() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceDefaultReviewersConditionCreate,
-		Read:   resourceDefaultReviewersConditionRead,
-		Exists: resourceDefaultReviewersConditionExists,
-		Delete: resourceDefaultReviewersConditionDelete,
+		Create:        resourceDefaultReviewersConditionCreate,
+		Read:          resourceDefaultReviewersConditionRead,
+		Update:        resourceDefaultReviewersConditionUpdate,
+		Exists:        resourceDefaultReviewersConditionExists,
+		Delete:        resourceDefaultReviewersConditionDelete,
+		CustomizeDiff: resourceDefaultReviewersConditionCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -81,7 +87,6 @@ func resourceDefaultReviewersCondition() *schema.Resource {
 					Type: schema.TypeString,
 				},
 				Required:    true,
-				ForceNew:    true,
 				Description: matcherDesc,
 			},
 			"target_matcher": {
@@ -90,22 +95,36 @@ func resourceDefaultReviewersCondition() *schema.Resource {
 					Type: schema.TypeString,
 				},
 				Required:    true,
-				ForceNew:    true,
 				Description: matcherDesc,
 			},
 			"reviewers": {
+				Type:          schema.TypeSet,
+				Elem:          &schema.Schema{Type: schema.TypeInt},
+				Optional:      true,
+				Computed:      true,
+				Set:           schema.HashInt,
+				MinItems:      1,
+				ConflictsWith: []string{"reviewer_usernames", "reviewer_groups"},
+				AtLeastOneOf:  []string{"reviewers", "reviewer_usernames", "reviewer_groups"},
+				Description:   "IDs of users to become default reviewers when you create a pull request. Computed from reviewer_usernames and reviewer_groups if those are used.",
+			},
+			"reviewer_usernames": {
 				Type:        schema.TypeSet,
-				Elem:        &schema.Schema{Type: schema.TypeInt},
-				Required:    true,
-				Set:         schema.HashInt,
-				ForceNew:    true,
-				MinItems:    1,
-				Description: "IDs of users to become default reviewers when you create a pull request.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Set:         schema.HashString,
+				Description: "Names of users to become default reviewers when you create a pull request.",
+			},
+			"reviewer_groups": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Set:         schema.HashString,
+				Description: "Groups whose members become default reviewers when you create a pull request.",
 			},
 			"required_approvals": {
 				Type:        schema.TypeInt,
 				Required:    true,
-				ForceNew:    true,
 				Description: "The number of default reviewers that must approve a pull request.",
 			},
 		},
@@ -225,7 +244,7 @@ func getReadConditionURI(projectKey string, repositorySlug string) string {
 	)
 }
 
-func getDeleteConditionURI(conditionID string, projectKey string, repositorySlug string) string {
+func getConditionURI(conditionID string, projectKey string, repositorySlug string) string {
 	if repositorySlug == "" {
 		return fmt.Sprintf("/rest/default-reviewers/1.0/projects/%s/condition/%s",
 			url.PathEscape(projectKey),
@@ -261,33 +280,163 @@ func selectConditionByID(conditions []DefaultReviewersConditionResp, conditionID
 	return nil
 }
 
-func resourceDefaultReviewersConditionCreate(d *schema.ResourceData, m interface{}) error {
-	projectKey := d.Get("project_key").(string)
-	repositorySlug := d.Get("repository_slug").(string)
+func resourceDefaultReviewersConditionCustomizeDiff(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	usernames := d.Get("reviewer_usernames").(*schema.Set)
+	groups := d.Get("reviewer_groups").(*schema.Set)
+
+	if !d.NewValueKnown("reviewer_usernames") || !d.NewValueKnown("reviewer_groups") {
+		return d.SetNewComputed("reviewers")
+	}
+
+	var reviewerCount int
+
+	if usernames.Len() > 0 || groups.Len() > 0 {
+		reviewers, found, err := resolveReviewers(m, usernames, groups)
+		if err != nil {
+			return err
+		}
+
+		if !found {
+			// the users or groups may be created in the same apply, they are resolved again on create or update
+			return d.SetNewComputed("reviewers")
+		}
+
+		err = d.SetNew("reviewers", collapseReviewers(reviewers))
+		if err != nil {
+			return err
+		}
+
+		reviewerCount = len(reviewers)
+	} else {
+		if !d.NewValueKnown("reviewers") {
+			return nil
+		}
+
+		reviewerCount = d.Get("reviewers").(*schema.Set).Len()
+	}
+
+	requiredApprovals := d.Get("required_approvals").(int)
+	if d.NewValueKnown("required_approvals") && requiredApprovals > reviewerCount {
+		return fmt.Errorf("required_approvals %d cannot be more than length of reviewers %d", requiredApprovals, reviewerCount)
+	}
+
+	return nil
+}
+
+// resolveReviewers looks up the IDs of the given users and the members of the given groups.
+// found is false if any of the users or groups does not exist (yet).
+func resolveReviewers(m interface{}, usernames *schema.Set, groups *schema.Set) ([]Reviewer, bool, error) {
+	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+
+	ids := make(map[int]bool)
+
+	for _, username := range usernames.List() {
+		resp, err := client.Get(fmt.Sprintf("/rest/api/1.0/users/%s",
+			url.PathEscape(username.(string)),
+		))
+
+		if resp != nil && resp.StatusCode == 404 {
+			return nil, false, nil
+		}
+
+		if err != nil {
+			return nil, false, err
+		}
+
+		var user User
+
+		decoder := json.NewDecoder(resp.Body)
+		err = decoder.Decode(&user)
+		if err != nil {
+			return nil, false, err
+		}
+
+		ids[user.UserId] = true
+	}
+
+	for _, group := range groups.List() {
+		users, err := readGroupUsers(m, group.(string), "")
+
+		var apiError bitbucketClient.Error
+		if errors.As(err, &apiError) && apiError.StatusCode == 404 {
+			return nil, false, nil
+		}
+
+		if err != nil {
+			return nil, false, err
+		}
+
+		for _, user := range users {
+			ids[user.Id] = true
+		}
+	}
+
+	reviewers := make([]Reviewer, 0, len(ids))
+	for id := range ids {
+		reviewers = append(reviewers, Reviewer{ID: id})
+	}
 
+	sort.Slice(reviewers, func(i, j int) bool {
+		return reviewers[i].ID < reviewers[j].ID
+	})
+
+	return reviewers, true, nil
+}
+
+func newDefaultReviewersConditionFromResource(d *schema.ResourceData, m interface{}) (*DefaultReviewersConditionPayload, error) {
 	sourceMatcher := expandMatcher(d.Get("source_matcher").(map[string]interface{}))
 	targetMatcher := expandMatcher(d.Get("target_matcher").(map[string]interface{}))
-	reviewers := expandReviewers(d.Get("reviewers").(*schema.Set))
 	requiredApprovals := d.Get("required_approvals").(int)
 
+	usernames := d.Get("reviewer_usernames").(*schema.Set)
+	groups := d.Get("reviewer_groups").(*schema.Set)
+
+	var reviewers []Reviewer
+	if usernames.Len() > 0 || groups.Len() > 0 {
+		resolved, found, err := resolveReviewers(m, usernames, groups)
+		if err != nil {
+			return nil, err
+		}
+
+		if !found {
+			return nil, fmt.Errorf("not all of reviewer_usernames %v and reviewer_groups %v exist", usernames.List(), groups.List())
+		}
+
+		reviewers = resolved
+	} else {
+		reviewers = expandReviewers(d.Get("reviewers").(*schema.Set))
+	}
+
 	if !contains(validMatcherTypeIDs, sourceMatcher.Type.ID) {
-		return fmt.Errorf("source_matcher.type_id %s must be one of %v", sourceMatcher.Type.ID, validMatcherTypeIDs)
+		return nil, fmt.Errorf("source_matcher.type_id %s must be one of %v", sourceMatcher.Type.ID, validMatcherTypeIDs)
 	}
 
 	if !contains(validMatcherTypeIDs, targetMatcher.Type.ID) {
-		return fmt.Errorf("target_matcher.type_id %s must be one of %v", targetMatcher.Type.ID, validMatcherTypeIDs)
+		return nil, fmt.Errorf("target_matcher.type_id %s must be one of %v", targetMatcher.Type.ID, validMatcherTypeIDs)
 	}
 
 	if requiredApprovals > len(reviewers) {
-		return fmt.Errorf("required_approvals %d cannot be more than length of reviewers %d", requiredApprovals, len(reviewers))
+		return nil, fmt.Errorf("required_approvals %d cannot be more than length of reviewers %d", requiredApprovals, len(reviewers))
 	}
 
-	bytedata, err := json.Marshal(&DefaultReviewersConditionPayload{
+	return &DefaultReviewersConditionPayload{
 		SourceMatcher:     sourceMatcher,
 		TargetMatcher:     targetMatcher,
 		Reviewers:         reviewers,
 		RequiredApprovals: strconv.Itoa(requiredApprovals),
-	})
+	}, nil
+}
+
+func resourceDefaultReviewersConditionCreate(d *schema.ResourceData, m interface{}) error {
+	projectKey := d.Get("project_key").(string)
+	repositorySlug := d.Get("repository_slug").(string)
+
+	payload, err := newDefaultReviewersConditionFromResource(d, m)
+	if err != nil {
+		return err
+	}
+
+	bytedata, err := json.Marshal(payload)
 
 	if err != nil {
 		return err
@@ -320,6 +469,35 @@ func resourceDefaultReviewersConditionCreate(d *schema.ResourceData, m interface
 	return resourceDefaultReviewersConditionRead(d, m)
 }
 
+func resourceDefaultReviewersConditionUpdate(d *schema.ResourceData, m interface{}) error {
+	conditionID, projectKey, repositorySlug, err := parseResourceID(d.Id())
+
+	if err != nil {
+		return err
+	}
+
+	payload, err := newDefaultReviewersConditionFromResource(d, m)
+	if err != nil {
+		return err
+	}
+
+	bytedata, err := json.Marshal(payload)
+
+	if err != nil {
+		return err
+	}
+
+	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+
+	_, err = client.Put(getConditionURI(conditionID, projectKey, repositorySlug), bytes.NewBuffer(bytedata))
+
+	if err != nil {
+		return err
+	}
+
+	return resourceDefaultReviewersConditionRead(d, m)
+}
+
 func resourceDefaultReviewersConditionRead(d *schema.ResourceData, m interface{}) error {
 	conditionID, projectKey, repositorySlug, err := parseResourceID(d.Id())
 
@@ -412,7 +590,7 @@ func resourceDefaultReviewersConditionDelete(d *schema.ResourceData, m interface
 
 	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
 
-	_, err = client.Delete(getDeleteConditionURI(conditionID, projectKey, repositorySlug))
+	_, err = client.Delete(getConditionURI(conditionID, projectKey, repositorySlug))
 
 	return err
 }
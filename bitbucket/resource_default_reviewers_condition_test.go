@@ -104,6 +104,49 @@ func TestAccBitbucketDefaultReviewersCondition_expectRequiredApprovalsError(t *t
 	})
 }
 
+func TestAccBitbucketDefaultReviewersCondition_usernamesAndGroups(t *testing.T) {
+	key := fmt.Sprintf("%v", rand.New(rand.NewSource(time.Now().UnixNano())).Int())
+	var conditionID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBitbucketDefaultReviewersConditionResourceWithUsernames(key, `reviewer_usernames = ["admin"]`, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucketserver_default_reviewers_condition.test", "reviewers.#", "1"),
+					resource.TestCheckResourceAttr("bitbucketserver_default_reviewers_condition.test", "reviewer_usernames.#", "1"),
+					resource.TestCheckResourceAttr("bitbucketserver_default_reviewers_condition.test", "required_approvals", "1"),
+					func(s *terraform.State) error {
+						conditionID = s.RootModule().Resources["bitbucketserver_default_reviewers_condition.test"].Primary.ID
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccBitbucketDefaultReviewersConditionResourceWithUsernames(key, `reviewer_groups = ["stash-users"]`, 0),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucketserver_default_reviewers_condition.test", "reviewers.#", "1"),
+					resource.TestCheckResourceAttr("bitbucketserver_default_reviewers_condition.test", "reviewer_groups.#", "1"),
+					resource.TestCheckResourceAttr("bitbucketserver_default_reviewers_condition.test", "required_approvals", "0"),
+					func(s *terraform.State) error {
+						if id := s.RootModule().Resources["bitbucketserver_default_reviewers_condition.test"].Primary.ID; id != conditionID {
+							return fmt.Errorf("expected condition %s to be updated in place, got %s", conditionID, id)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config:      testAccBitbucketDefaultReviewersConditionResourceWithUsernames(key, `reviewer_usernames = ["admin"]`, 2),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("required_approvals 2 cannot be more than length of reviewers 1"),
+			},
+		},
+	})
+}
+
 func testAccCheckBitbucketDefaultReviewersConditionDestroy(s *terraform.State) error {
 	_, ok := s.RootModule().Resources["bitbucketserver_default_reviewers_condition.test"]
 
@@ -171,3 +214,25 @@ resource "bitbucketserver_default_reviewers_condition" "test" {
 	required_approvals = 1
 }`, key, key, key)
 }
+
+func testAccBitbucketDefaultReviewersConditionResourceWithUsernames(key string, reviewers string, requiredApprovals int) string {
+	return fmt.Sprintf(`
+resource "bitbucketserver_project" "test" {
+	key = "TEST%s"
+	name = "test-project-%s"
+}
+
+resource "bitbucketserver_default_reviewers_condition" "test" {
+	project_key			= bitbucketserver_project.test.key
+	source_matcher		= {
+		id			= "any"
+		type_id		= "ANY_REF"
+	}
+	target_matcher		= {
+		id			= "any"
+		type_id		= "ANY_REF"
+	}
+	%s
+	required_approvals = %d
+}`, key, key, reviewers, requiredApprovals)
+}
@@ -0,0 +1,218 @@
+package bitbucket
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/xvlcwk-terraform/terraform-provider-bitbucketserver/bitbucket/util/client"
+	bitbucketTypes "github.com/xvlcwk-terraform/terraform-provider-bitbucketserver/bitbucket/util/types"
+	"log"
+	"sort"
+)
+
+// groupMembersBatchSize limits how many users are sent to Bitbucket in a single add-users call.
+const groupMembersBatchSize = 100
+
+type GroupMembersRequest struct {
+	Group string   `json:"group,omitempty"`
+	Users []string `json:"users,omitempty"`
+}
+
+func resourceGroupMembers() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGroupMembersCreate,
+		Read:   resourceGroupMembersRead,
+		Update: resourceGroupMembersUpdate,
+		Delete: resourceGroupMembersDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"group": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"users": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Optional:    true,
+				Description: "The complete list of users in the group. Users not listed are removed from the group.",
+			},
+		},
+	}
+}
+
+func resourceGroupMembersCreate(d *schema.ResourceData, m interface{}) error {
+	group := d.Get("group").(string)
+
+	existing, err := readGroupMemberNames(m, group)
+	if err != nil {
+		return err
+	}
+
+	err = applyGroupMembers(m, group, existing, d.Get("users").(*schema.Set))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(group)
+
+	return resourceGroupMembersRead(d, m)
+}
+
+func resourceGroupMembersUpdate(d *schema.ResourceData, m interface{}) error {
+	group := d.Get("group").(string)
+
+	if d.HasChange("users") {
+		existing, err := readGroupMemberNames(m, group)
+		if err != nil {
+			return err
+		}
+
+		err = applyGroupMembers(m, group, existing, d.Get("users").(*schema.Set))
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceGroupMembersRead(d, m)
+}
+
+func resourceGroupMembersRead(d *schema.ResourceData, m interface{}) error {
+	id := d.Id()
+	if id != "" {
+		_ = d.Set("group", id)
+	}
+
+	group := d.Get("group").(string)
+
+	groupMatches, err := readGroups(m, group)
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for _, g := range groupMatches {
+		if g == group {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		d.SetId("")
+		log.Printf("[WARN] Group %s not found, removing from state", group)
+		return nil
+	}
+
+	members, err := readGroupMemberNames(m, group)
+	if err != nil {
+		return err
+	}
+
+	_ = d.Set("users", members)
+	return nil
+}
+
+func resourceGroupMembersDelete(d *schema.ResourceData, m interface{}) error {
+	group := d.Get("group").(string)
+	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+
+	for _, user := range d.Get("users").(*schema.Set).List() {
+		err := removeGroupMember(client, group, user.(string))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyGroupMembers adds and removes users so that the membership of group matches desired.
+func applyGroupMembers(m interface{}, group string, existing []string, desired *schema.Set) error {
+	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+
+	current := make(map[string]bool, len(existing))
+	for _, user := range existing {
+		current[user] = true
+	}
+
+	var toAdd []string
+	for _, user := range desired.List() {
+		if !current[user.(string)] {
+			toAdd = append(toAdd, user.(string))
+		}
+	}
+	sort.Strings(toAdd)
+
+	for start := 0; start < len(toAdd); start += groupMembersBatchSize {
+		end := start + groupMembersBatchSize
+		if end > len(toAdd) {
+			end = len(toAdd)
+		}
+
+		err := addGroupMembers(client, group, toAdd[start:end])
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, user := range existing {
+		if !desired.Contains(user) {
+			err := removeGroupMember(client, group, user)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func addGroupMembers(client *client.BitbucketClient, group string, users []string) error {
+	bytedata, err := json.Marshal(&GroupMembersRequest{
+		Group: group,
+		Users: users,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Post("/rest/api/1.0/admin/groups/add-users", bytes.NewBuffer(bytedata))
+	return err
+}
+
+func removeGroupMember(client *client.BitbucketClient, group string, user string) error {
+	type RemoveRequest struct {
+		Group string `json:"context,omitempty"`
+		User  string `json:"itemName,omitempty"`
+	}
+
+	bytedata, err := json.Marshal(&RemoveRequest{
+		Group: group,
+		User:  user,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Post("/rest/api/1.0/admin/groups/remove-user", bytes.NewBuffer(bytedata))
+	return err
+}
+
+func readGroupMemberNames(m interface{}, group string) ([]string, error) {
+	users, err := readGroupUsers(m, group, "")
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(users))
+	for _, user := range users {
+		names = append(names, user.Name)
+	}
+
+	return names, nil
+}
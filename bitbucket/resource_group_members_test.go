@@ -0,0 +1,82 @@
+package bitbucket
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"testing"
+)
+
+func TestAccBitbucketResourceGroupMembers_basic(t *testing.T) {
+	config := `
+		resource "bitbucketserver_group" "test" {
+			name = "test-group-members"
+		}
+
+		resource "bitbucketserver_user" "mreynolds" {
+		  name          = "mreynolds"
+		  display_name  = "Malcolm Reynolds"
+		  email_address = "browncoat@example.com"
+		}
+
+		resource "bitbucketserver_user" "zwashburne" {
+		  name          = "zwashburne"
+		  display_name  = "Zoe Washburne"
+		  email_address = "zoe@example.com"
+		}
+
+		resource "bitbucketserver_group_members" "test" {
+			group = bitbucketserver_group.test.name
+			users = [bitbucketserver_user.mreynolds.name, bitbucketserver_user.zwashburne.name]
+		}
+	`
+
+	configRemoved := `
+		resource "bitbucketserver_group" "test" {
+			name = "test-group-members"
+		}
+
+		resource "bitbucketserver_user" "mreynolds" {
+		  name          = "mreynolds"
+		  display_name  = "Malcolm Reynolds"
+		  email_address = "browncoat@example.com"
+		}
+
+		resource "bitbucketserver_user" "zwashburne" {
+		  name          = "zwashburne"
+		  display_name  = "Zoe Washburne"
+		  email_address = "zoe@example.com"
+		}
+
+		resource "bitbucketserver_group_members" "test" {
+			group = bitbucketserver_group.test.name
+			users = [bitbucketserver_user.zwashburne.name]
+		}
+	`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucketserver_group_members.test", "group", "test-group-members"),
+					resource.TestCheckResourceAttr("bitbucketserver_group_members.test", "users.#", "2"),
+					resource.TestCheckTypeSetElemAttr("bitbucketserver_group_members.test", "users.*", "mreynolds"),
+					resource.TestCheckTypeSetElemAttr("bitbucketserver_group_members.test", "users.*", "zwashburne"),
+				),
+			},
+			{
+				Config: configRemoved,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucketserver_group_members.test", "users.#", "1"),
+					resource.TestCheckTypeSetElemAttr("bitbucketserver_group_members.test", "users.*", "zwashburne"),
+				),
+			},
+			{
+				ResourceName:      "bitbucketserver_group_members.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -2,11 +2,17 @@ package bitbucket
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	bitbucketTypes "github.com/xvlcwk-terraform/terraform-provider-bitbucketserver/bitbucket/util/types"
 	"io/ioutil"
+	"log"
+	"strings"
+	"time"
 )
 
 type Project struct {
@@ -19,14 +25,17 @@ type Project struct {
 
 func resourceProject() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceProjectCreate,
-		Update: resourceProjectUpdate,
-		Read:   resourceProjectRead,
-		Exists: resourceProjectExists,
-		Delete: resourceProjectDelete,
+		Create:        resourceProjectCreate,
+		Update:        resourceProjectUpdate,
+		Read:          resourceProjectRead,
+		Exists:        resourceProjectExists,
+		DeleteContext: resourceProjectDelete,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -51,6 +60,12 @@ func resourceProject() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"force_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Delete all repositories of the project when destroying it.",
+			},
 		},
 	}
 }
@@ -174,12 +189,72 @@ func resourceProjectExists(d *schema.ResourceData, m interface{}) (bool, error)
 	}
 }
 
-func resourceProjectDelete(d *schema.ResourceData, m interface{}) error {
+func resourceProjectDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	project := d.Get("key").(string)
 	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
-	_, err := client.Delete(fmt.Sprintf("/rest/api/1.0/projects/%s",
-		project,
-	))
 
-	return err
+	// the repositories are always deleted, archived repositories would still block the deletion of the project
+	if d.Get("force_destroy").(bool) {
+		repositories, err := readProjectRepositories(client, project)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		for _, repository := range repositories {
+			log.Printf("[DEBUG] Deleting repository %s/%s of project to be destroyed", project, repository.Slug)
+			_, err = client.Delete(fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s",
+				project,
+				repository.Slug,
+			))
+			if err != nil {
+				return diag.FromErr(err)
+			}
+
+			err = waitForRepositoryDeletion(ctx, client, project, repository.Slug, d.Timeout(schema.TimeoutDelete))
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	// the deletion is refused with a conflict while the project contains repositories,
+	// this includes repositories which are still being deleted
+	err := retry.RetryContext(ctx, d.Timeout(schema.TimeoutDelete), func() *retry.RetryError {
+		resp, err := client.Delete(fmt.Sprintf("/rest/api/1.0/projects/%s",
+			project,
+		))
+
+		if resp != nil && resp.StatusCode == 404 {
+			return nil
+		}
+
+		if resp != nil && resp.StatusCode == 409 {
+			repositories, readErr := readProjectRepositories(client, project)
+			if readErr != nil {
+				return retry.NonRetryableError(readErr)
+			}
+
+			if len(repositories) > 0 {
+				var slugs []string
+				for _, repository := range repositories {
+					slugs = append(slugs, repository.Slug)
+				}
+
+				return retry.NonRetryableError(fmt.Errorf("project %s still contains the repositories %s, remove them or set force_destroy = true",
+					project,
+					strings.Join(slugs, ", "),
+				))
+			}
+
+			return retry.RetryableError(err)
+		}
+
+		if err != nil {
+			return retry.NonRetryableError(err)
+		}
+
+		return nil
+	})
+
+	return diag.FromErr(err)
 }
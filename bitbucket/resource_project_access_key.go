@@ -0,0 +1,227 @@
+package bitbucket
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	bitbucketTypes "github.com/xvlcwk-terraform/terraform-provider-bitbucketserver/bitbucket/util/types"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+type ProjectKeyRequestKey struct {
+	Id         int    `json:"id,omitempty"`
+	Label      string `json:"label,omitempty"`
+	Text       string `json:"text,omitempty"`
+	ExpiryDays int    `json:"expiryDays,omitempty"`
+}
+
+type ProjectKeyRequest struct {
+	Key        ProjectKeyRequestKey `json:"key"`
+	Permission string               `json:"permission"`
+}
+
+type ProjectKeyResponse struct {
+	Key struct {
+		Id         int    `json:"id"`
+		ExpiryDays int    `json:"expiryDays"`
+		Label      string `json:"label"`
+		Text       string `json:"text"`
+	} `json:"key"`
+	Permission string `json:"permission"`
+	Project    struct {
+		Key string `json:"key"`
+	} `json:"project"`
+}
+
+func resourceProjectAccessKey() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceProjectAccessKeyCreate,
+		Read:   resourceProjectAccessKeyRead,
+		Update: resourceProjectAccessKeyUpdate,
+		Delete: resourceProjectAccessKeyDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"key", "key_id"},
+				StateFunc: func(val interface{}) string {
+					return strings.TrimSpace(val.(string))
+				},
+				Description: "The public key to grant access to.",
+			},
+			"key_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "Id of a public key already registered in Bitbucket, e.g. as access key of another project or repository.",
+			},
+			"label": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"permission": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"PROJECT_READ", "PROJECT_WRITE"}, false),
+			},
+			"expiry_days": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+		},
+	}
+}
+
+func getProjectAccessKeysURI(project string) string {
+	return fmt.Sprintf("/rest/keys/1.0/projects/%s/ssh",
+		url.PathEscape(project),
+	)
+}
+
+func parseProjectAccessKeyID(id string) (project string, keyID string, err error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("incorrect ID format, should match `project/key_id`")
+	}
+
+	return parts[0], parts[1], nil
+}
+
+func resourceProjectAccessKeyCreate(d *schema.ResourceData, m interface{}) error {
+	project := d.Get("project").(string)
+
+	keyRequest := &ProjectKeyRequest{
+		Key: ProjectKeyRequestKey{
+			Id:         d.Get("key_id").(int),
+			Text:       strings.TrimSpace(d.Get("key").(string)),
+			ExpiryDays: d.Get("expiry_days").(int),
+		},
+		Permission: d.Get("permission").(string),
+	}
+
+	// the label belongs to the key, it can only be set when a new key is registered
+	if keyRequest.Key.Id == 0 {
+		keyRequest.Key.Label = d.Get("label").(string)
+	}
+
+	request, err := json.Marshal(keyRequest)
+	if err != nil {
+		return err
+	}
+
+	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+	resp, err := client.Post(getProjectAccessKeysURI(project), bytes.NewBuffer(request))
+	if err != nil {
+		return err
+	}
+
+	var keyResponse ProjectKeyResponse
+
+	decoder := json.NewDecoder(resp.Body)
+	err = decoder.Decode(&keyResponse)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%d", project, keyResponse.Key.Id))
+
+	return resourceProjectAccessKeyRead(d, m)
+}
+
+func resourceProjectAccessKeyUpdate(d *schema.ResourceData, m interface{}) error {
+	project, keyID, err := parseProjectAccessKeyID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if d.HasChange("permission") {
+		client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+		_, err = client.PutOnly(fmt.Sprintf("%s/%s/permission/%s",
+			getProjectAccessKeysURI(project),
+			url.PathEscape(keyID),
+			url.PathEscape(d.Get("permission").(string)),
+		))
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceProjectAccessKeyRead(d, m)
+}
+
+func resourceProjectAccessKeyRead(d *schema.ResourceData, m interface{}) error {
+	project, keyID, err := parseProjectAccessKeyID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+	resp, err := client.Get(fmt.Sprintf("%s/%s",
+		getProjectAccessKeysURI(project),
+		url.PathEscape(keyID),
+	))
+
+	if resp != nil && resp.StatusCode == 404 {
+		log.Printf("[WARN] Project access key %s not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	var keyResponse ProjectKeyResponse
+
+	decoder := json.NewDecoder(resp.Body)
+	err = decoder.Decode(&keyResponse)
+	if err != nil {
+		return err
+	}
+
+	id, _ := strconv.Atoi(keyID)
+	_ = d.Set("project", project)
+	_ = d.Set("key_id", id)
+	_ = d.Set("key", strings.TrimSpace(keyResponse.Key.Text))
+	_ = d.Set("label", keyResponse.Key.Label)
+	_ = d.Set("permission", keyResponse.Permission)
+	_ = d.Set("expiry_days", keyResponse.Key.ExpiryDays)
+
+	return nil
+}
+
+func resourceProjectAccessKeyDelete(d *schema.ResourceData, m interface{}) error {
+	project, keyID, err := parseProjectAccessKeyID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+	_, err = client.Delete(fmt.Sprintf("%s/%s",
+		getProjectAccessKeysURI(project),
+		url.PathEscape(keyID),
+	))
+
+	return err
+}
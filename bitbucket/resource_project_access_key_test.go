@@ -0,0 +1,72 @@
+package bitbucket
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccBitbucketResourceProjectAccessKey(t *testing.T) {
+	projectKey := fmt.Sprintf("TEST%v", rand.New(rand.NewSource(time.Now().UnixNano())).Int())
+	configString := `
+		resource "bitbucketserver_project" "test" {
+			key  = "%v"
+			name = "test-project-%v"
+		}
+
+		resource "bitbucketserver_project" "other" {
+			key  = "%vO"
+			name = "test-project-%v-other"
+		}
+
+		resource "bitbucketserver_project_access_key" "test" {
+			project     = bitbucketserver_project.test.key
+			label       = "projectKeyForTest"
+			key         = "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABgQD1F2KTOi8ptpqkXdcARgYy27uWRCav1isJUB3Hz59MDM6CSAXa+HCgUNDV+6gXQ1eR24nr1Efb7AkEM8LmvMkNlQqpAsPEPxVlndA0KSRLXb3mWruJzkZtrJo1HhVDffuKnevPLFkB75wyBX3jn1FNtc2qfc2i80mu8TfviZnOPYnrRa8B2S9Q8IlUtXAyihQ1G3fn5r5nxrw3QQGrD3cckB9nCZpzAPn6hlDHVk6n5efoWAUxM5AhKln0OLsA1HwjGJN7/dbDPu1nSLuiJSAISSSg4E4SNdfnr3FOhTA79AKNzsor2/EXdbG+f+S4op3s3wtt05zwkHLXRSqKYoT31RFiV9d1XIav+dGTvXCgc6DNG6rbogE6ZbugDZXdcHOAoNs7IUDFbtI/HGKS7CStxAUEchoxM8HDYXmhYt1kUEpmP3g2ckILHGoGPEkOCYGPqx5HbDvAXAJVk3DdSOibCckR2FK2qEoCbMgnPUX84CqNJPHBZ24AaE8htE6TKr0= user@somewhere\n"
+			permission  = "%v"
+			expiry_days = 90
+		}
+
+		resource "bitbucketserver_project_access_key" "reused" {
+			project    = bitbucketserver_project.other.key
+			key_id     = bitbucketserver_project_access_key.test.key_id
+			permission = "PROJECT_READ"
+		}
+	`
+
+	config := fmt.Sprintf(configString, projectKey, projectKey, projectKey, projectKey, "PROJECT_READ")
+	newConfig := fmt.Sprintf(configString, projectKey, projectKey, projectKey, projectKey, "PROJECT_WRITE")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("bitbucketserver_project_access_key.test", "key_id"),
+					resource.TestCheckResourceAttr("bitbucketserver_project_access_key.test", "project", projectKey),
+					resource.TestCheckResourceAttr("bitbucketserver_project_access_key.test", "label", "projectKeyForTest"),
+					resource.TestCheckResourceAttr("bitbucketserver_project_access_key.test", "permission", "PROJECT_READ"),
+					resource.TestCheckResourceAttr("bitbucketserver_project_access_key.test", "expiry_days", "90"),
+					resource.TestCheckResourceAttrPair("bitbucketserver_project_access_key.reused", "key_id", "bitbucketserver_project_access_key.test", "key_id"),
+					resource.TestCheckResourceAttrPair("bitbucketserver_project_access_key.reused", "key", "bitbucketserver_project_access_key.test", "key"),
+				),
+			},
+			{
+				Config: newConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucketserver_project_access_key.test", "permission", "PROJECT_WRITE"),
+				),
+			},
+			{
+				ResourceName:      "bitbucketserver_project_access_key.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,276 @@
+package bitbucket
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/xvlcwk-terraform/terraform-provider-bitbucketserver/bitbucket/util"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type projectAccessTokenModel struct {
+	Id               types.String `tfsdk:"id"`
+	Name             types.String `tfsdk:"name"`
+	Permissions      types.Set    `tfsdk:"permissions"`
+	ExpireIn         types.Int64  `tfsdk:"expire_in"`
+	Project          types.String `tfsdk:"project"`
+	Token            types.String `tfsdk:"token"`
+	CreatedDate      types.Int64  `tfsdk:"created_date"`
+	RotateBeforeDays types.Int64  `tfsdk:"rotate_before_days"`
+	Keepers          types.Map    `tfsdk:"keepers"`
+}
+
+type projectAccessTokenResource struct {
+	resourceHelper *util.AccessTokenResourceHelper
+}
+
+func newProjectAccessTokenResource() resource.Resource {
+	return &projectAccessTokenResource{
+		resourceHelper: util.NewAccessTokenResourceHelper(),
+	}
+}
+
+// Ensure the implementation satisfies the desired interfaces.
+var _ resource.ResourceWithConfigure = &projectAccessTokenResource{}
+var _ resource.ResourceWithModifyPlan = &projectAccessTokenResource{}
+var _ resource.ResourceWithImportState = &projectAccessTokenResource{}
+
+// Metadata should return the full name of the resource.
+func (r *projectAccessTokenResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project_access_token"
+}
+
+// Schema should return the schema for this resource.
+func (r *projectAccessTokenResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "An HTTP-Access token limited to the repositories of the given project",
+		Attributes: r.resourceHelper.Schema(map[string]schema.Attribute{
+			"project": schema.StringAttribute{
+				Required:    true,
+				Description: "The project key",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"permissions": schema.SetAttribute{
+				Required:    true,
+				Description: "The permissions this access token has for the project and its repositories.",
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(
+						stringvalidator.OneOf("PROJECT_READ", "PROJECT_WRITE", "PROJECT_ADMIN", "REPO_READ", "REPO_WRITE", "REPO_ADMIN"),
+					),
+				},
+			},
+		}),
+	}
+}
+
+func (r *projectAccessTokenResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data projectAccessTokenModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	payload, diagnostics := r.createRequestData(ctx, data)
+	if diagnostics != nil {
+		resp.Diagnostics.Append(diagnostics...)
+		return
+	}
+
+	tokenResponse, tokenErrorResponse := r.resourceHelper.Client.Put(r.getUrlForProject(data), bytes.NewBuffer(payload))
+	response, convertingResponseDiagnostics := r.readResponse(tokenErrorResponse, tokenResponse, &data)
+	if convertingResponseDiagnostics != nil {
+		resp.Diagnostics.Append(convertingResponseDiagnostics)
+		return
+	}
+
+	data.Token = types.StringValue(response.Token)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *projectAccessTokenResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data projectAccessTokenModel
+
+	// Read Terraform state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tokenResponse, tokenErrorResponse := r.resourceHelper.Client.Get(r.getUrlForId(data))
+	if tokenResponse != nil && tokenResponse.StatusCode == 404 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	response, diagnostic := r.readResponse(tokenErrorResponse, tokenResponse, &data)
+	if diagnostic != nil {
+		resp.Diagnostics.Append(diagnostic)
+		return
+	}
+
+	if len(response.Permissions) > 0 {
+		permissions, permissionDiagnostics := types.SetValueFrom(ctx, types.StringType, response.Permissions)
+		resp.Diagnostics.Append(permissionDiagnostics...)
+		data.Permissions = permissions
+	}
+
+	// after an import only the expiry date is known, the validity is derived from it
+	if data.ExpireIn.IsNull() && response.ExpiryDate > 0 {
+		data.ExpireIn = types.Int64Value((response.ExpiryDate - response.CreatedDate) / (24 * 60 * 60 * 1000))
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *projectAccessTokenResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data projectAccessTokenModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	payload, diagnostics := r.createRequestData(ctx, data)
+	if diagnostics != nil {
+		resp.Diagnostics.Append(diagnostics...)
+		return
+	}
+
+	tokenResponse, tokenErrorResponse := r.resourceHelper.Client.Post(r.getUrlForId(data), bytes.NewBuffer(payload))
+	_, convertingResponseDiagnostics := r.readResponse(tokenErrorResponse, tokenResponse, &data)
+	if convertingResponseDiagnostics != nil {
+		resp.Diagnostics.Append(convertingResponseDiagnostics)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *projectAccessTokenResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data projectAccessTokenModel
+
+	// Read Terraform state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tokenResponse, tokenErrorResponse := r.resourceHelper.Client.Delete(r.getUrlForId(data))
+	if tokenResponse != nil && tokenResponse.StatusCode == 404 {
+		return
+	}
+
+	if tokenErrorResponse != nil {
+		resp.Diagnostics.Append(diag.NewErrorDiagnostic(
+			"Unable to Delete Resource",
+			"An unexpected error occurred while deleting the resource. "+
+				"Please report this issue to the provider developers.\n\n"+
+				"Error: "+tokenErrorResponse.Error()))
+		return
+	}
+
+	if tokenResponse.StatusCode != 204 {
+		resp.Diagnostics.Append(diag.NewErrorDiagnostic(
+			"Unable to Delete Resource",
+			"An unexpected statusCode occurred while deleting the resource. "+
+				"Please report this issue to the provider developers.\n\n"+
+				"Status: "+tokenResponse.Status))
+	}
+}
+
+// ImportState expects an ID in the format `project/id`.
+func (r *projectAccessTokenResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: project/id. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+}
+
+func (r *projectAccessTokenResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.resourceHelper.ModifyPlan(ctx, req, resp)
+}
+
+func (r *projectAccessTokenResource) Configure(ctx context.Context, configureRequest resource.ConfigureRequest, configureResponse *resource.ConfigureResponse) {
+	r.resourceHelper.Configure(ctx, configureRequest, configureResponse)
+}
+
+func (r *projectAccessTokenResource) createRequestData(ctx context.Context, data projectAccessTokenModel) ([]byte, diag.Diagnostics) {
+	var permissions []string
+	permissionConversionDiagnostics := data.Permissions.ElementsAs(ctx, &permissions, false)
+	if permissionConversionDiagnostics != nil {
+		return nil, permissionConversionDiagnostics
+	}
+	tokenRequest := &util.CreateAccessTokenRequest{
+		ExpiryDays:  data.ExpireIn.ValueInt64(),
+		Name:        data.Name.ValueString(),
+		Permissions: permissions,
+	}
+	payload, jsonEncodingError := json.Marshal(tokenRequest)
+	if jsonEncodingError != nil {
+		return nil, diag.Diagnostics{diag.NewErrorDiagnostic("Marshalling error", fmt.Sprintf("Failed to encode %v. Is it valid Json?", tokenRequest))}
+	}
+	return payload, nil
+}
+
+func (r *projectAccessTokenResource) readResponse(tokenErrorResponse error, tokenResponse *http.Response, data *projectAccessTokenModel) (*util.AccessTokenResponse, *diag.ErrorDiagnostic) {
+	if tokenErrorResponse != nil {
+		diagnostic := diag.NewErrorDiagnostic("http error", tokenErrorResponse.Error())
+		return nil, &diagnostic
+	}
+	if tokenResponse.StatusCode != 200 {
+		diagnostic := diag.NewErrorDiagnostic("http error", fmt.Sprintf("Response Status: %d", tokenResponse.StatusCode))
+		return nil, &diagnostic
+	}
+
+	body, readBodyError := io.ReadAll(tokenResponse.Body)
+	if readBodyError != nil {
+		diagnostic := diag.NewErrorDiagnostic("Error reading response", "Failed to read response. Is it valid Json?")
+		return nil, &diagnostic
+	}
+
+	response := &util.AccessTokenResponse{}
+	unmarshallError := json.Unmarshal(body, response)
+	if unmarshallError != nil {
+		diagnostic := diag.NewErrorDiagnostic("Error reading response", fmt.Sprintf("Failed to read response %v. Is it valid Json?, %v", string(body), unmarshallError))
+		return nil, &diagnostic
+	}
+
+	data.Id = types.StringValue(response.Id)
+	data.CreatedDate = types.Int64Value(response.CreatedDate)
+	data.Name = types.StringValue(response.Name)
+	return response, nil
+}
+
+func (r *projectAccessTokenResource) getUrlForId(data projectAccessTokenModel) string {
+	return fmt.Sprintf("%v/%v", r.getUrlForProject(data), url.PathEscape(data.Id.ValueString()))
+}
+
+func (r *projectAccessTokenResource) getUrlForProject(data projectAccessTokenModel) string {
+	return fmt.Sprintf("/rest/access-tokens/latest/projects/%v", url.PathEscape(data.Project.ValueString()))
+}
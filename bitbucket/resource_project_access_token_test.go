@@ -0,0 +1,65 @@
+package bitbucket
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccBitbucketResourceProjectAccessToken(t *testing.T) {
+	projectKey := fmt.Sprintf("TEST%v", rand.New(rand.NewSource(time.Now().UnixNano())).Int())
+
+	config := fmt.Sprintf(`
+		resource "bitbucketserver_project" "test" {
+			key = "%v"
+			name = "test-project-%v"
+		}
+
+		resource "bitbucketserver_project_access_token" "test" {
+			project = bitbucketserver_project.test.key
+			name = "newLabelForTest"
+			permissions = ["PROJECT_READ"]
+			rotate_before_days = 30
+		}
+	`, projectKey, projectKey)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("bitbucketserver_project_access_token.test", "id"),
+					resource.TestCheckResourceAttr("bitbucketserver_project_access_token.test", "project", projectKey),
+					resource.TestCheckResourceAttr("bitbucketserver_project_access_token.test", "name", "newLabelForTest"),
+					resource.TestCheckResourceAttr("bitbucketserver_project_access_token.test", "permissions.#", "1"),
+					resource.TestCheckResourceAttr("bitbucketserver_project_access_token.test", "permissions.0", "PROJECT_READ"),
+					resource.TestCheckResourceAttr("bitbucketserver_project_access_token.test", "expire_in", "1095"),
+					resource.TestCheckResourceAttrSet("bitbucketserver_project_access_token.test", "token"),
+					resource.TestCheckResourceAttr("bitbucketserver_project_access_token.test", "rotate_before_days", "30"),
+				),
+			},
+			{
+				// a window longer than the validity puts the token into rotation on every plan
+				Config:             strings.Replace(config, "rotate_before_days = 30", "rotate_before_days = 1100", 1),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				ResourceName:            "bitbucketserver_project_access_token.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"token", "rotate_before_days"},
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources["bitbucketserver_project_access_token.test"]
+					return fmt.Sprintf("%s/%s", projectKey, rs.Primary.ID), nil
+				},
+			},
+		},
+	})
+}
@@ -2,10 +2,14 @@ package bitbucket
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	bitbucketTypes "github.com/xvlcwk-terraform/terraform-provider-bitbucketserver/bitbucket/util/types"
 	"io/ioutil"
+	"log"
+	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -38,8 +42,9 @@ type BranchPermissionResponse struct {
 		ResourceID int    `json:"resourceId"`
 		Type       string `json:"type"`
 	} `json:"scope"`
-	Type  string `json:"type"`
-	Users []struct {
+	Type    string        `json:"type"`
+	Matcher MatcherStruct `json:"matcher"`
+	Users   []struct {
 		Name         string `json:"name"`
 		EmailAddress string `json:"emailAddress"`
 		ID           int    `json:"id"`
@@ -65,12 +70,24 @@ type AllRepositoryBranchPermissionsResponse struct {
 	Values     []BranchPermissionResponse `json:"values"`
 }
 
+var branchPermissionMatcherTypeNames = map[string]string{
+	"BRANCH":         "Branch",
+	"PATTERN":        "Pattern",
+	"MODEL_CATEGORY": "Branching model category",
+	"MODEL_BRANCH":   "Branching model branch",
+}
+
+var branchPermissionModelCategories = []string{"FEATURE", "BUGFIX", "HOTFIX", "RELEASE"}
+
+var branchPermissionModelBranches = []string{"development", "production"}
+
 func resourceBranchPermissions() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceBranchPermissionsCreate,
-		Read:   resourceBranchPermissionsRead,
-		Update: resourceBranchPermissionsCreate,
-		Delete: resourceBranchPermissionsDelete,
+		Create:        resourceBranchPermissionsCreate,
+		Read:          resourceBranchPermissionsRead,
+		Update:        resourceBranchPermissionsUpdate,
+		Delete:        resourceBranchPermissionsDelete,
+		CustomizeDiff: resourceBranchPermissionsCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -82,34 +99,43 @@ func resourceBranchPermissions() *schema.Resource {
 				ForceNew: true,
 			},
 			"repository": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Repository slug. If empty, the restriction applies to every repository in the project.",
 			},
 			"ref_pattern": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The id of the matcher. A ref for BRANCH, a pattern for PATTERN, one of FEATURE, BUGFIX, HOTFIX, RELEASE for MODEL_CATEGORY and one of development, production for MODEL_BRANCH.",
+			},
+			"matcher_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "PATTERN",
+				ValidateFunc: validation.StringInSlice([]string{"BRANCH", "PATTERN", "MODEL_CATEGORY", "MODEL_BRANCH"}, false),
 			},
 			"type": {
 				Type:         schema.TypeString,
 				Required:     true,
-				ForceNew:     true,
 				ValidateFunc: validation.StringInSlice([]string{"pull-request-only", "fast-forward-only", "no-deletes", "read-only"}, false),
 			},
 			"exception_users": {
-				Type:     schema.TypeList,
+				Type:     schema.TypeSet,
 				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
 				Optional: true,
 			},
 			"exception_groups": {
-				Type:     schema.TypeList,
+				Type:     schema.TypeSet,
 				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
 				Optional: true,
 			},
 			"exception_access_keys": {
-				Type:     schema.TypeList,
+				Type:     schema.TypeSet,
 				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
 				Optional: true,
 			},
 			"permission_id": {
@@ -120,29 +146,56 @@ func resourceBranchPermissions() *schema.Resource {
 	}
 }
 
+func resourceBranchPermissionsCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	matcherType := d.Get("matcher_type").(string)
+	refPattern := d.Get("ref_pattern").(string)
+
+	if !d.NewValueKnown("ref_pattern") {
+		return nil
+	}
+
+	switch matcherType {
+	case "MODEL_CATEGORY":
+		if !contains(branchPermissionModelCategories, refPattern) {
+			return fmt.Errorf("ref_pattern %s must be one of %v when matcher_type is MODEL_CATEGORY", refPattern, branchPermissionModelCategories)
+		}
+	case "MODEL_BRANCH":
+		if !contains(branchPermissionModelBranches, refPattern) {
+			return fmt.Errorf("ref_pattern %s must be one of %v when matcher_type is MODEL_BRANCH", refPattern, branchPermissionModelBranches)
+		}
+	case "BRANCH":
+		if !strings.HasPrefix(refPattern, "refs/heads/") {
+			return fmt.Errorf("ref_pattern %s must be a fully qualified branch like refs/heads/main when matcher_type is BRANCH", refPattern)
+		}
+	}
+
+	return nil
+}
+
 func newBranchPermissionPayloadFromResource(d *schema.ResourceData) *BranchPermissionPayload {
 	branchPermissionPayload := &BranchPermissionPayload{
 		Type: d.Get("type").(string),
 	}
 
-	for _, item := range d.Get("exception_users").([]interface{}) {
+	for _, item := range d.Get("exception_users").(*schema.Set).List() {
 		branchPermissionPayload.Users = append(branchPermissionPayload.Users, item.(string))
 	}
 
-	for _, item := range d.Get("exception_groups").([]interface{}) {
+	for _, item := range d.Get("exception_groups").(*schema.Set).List() {
 		branchPermissionPayload.Groups = append(branchPermissionPayload.Groups, item.(string))
 	}
 
-	for _, item := range d.Get("exception_access_keys").([]interface{}) {
+	for _, item := range d.Get("exception_access_keys").(*schema.Set).List() {
 		branchPermissionPayload.AccessKeys = append(branchPermissionPayload.AccessKeys, item.(string))
 	}
 
+	matcherType := d.Get("matcher_type").(string)
 	matcherConfig := &MatcherStruct{
 		Id:        d.Get("ref_pattern").(string),
 		DisplayId: d.Get("ref_pattern").(string),
 		Type: MatcherStructType{
-			Id:   "PATTERN",
-			Name: "Pattern",
+			Id:   matcherType,
+			Name: branchPermissionMatcherTypeNames[matcherType],
 		},
 		Active: true,
 	}
@@ -165,10 +218,7 @@ func resourceBranchPermissionsCreate(d *schema.ResourceData, m interface{}) erro
 		return err
 	}
 
-	res, err := client.Post(fmt.Sprintf("/rest/branch-permissions/2.0/projects/%s/repos/%s/restrictions",
-		project,
-		repository,
-	), bytes.NewBuffer(request))
+	res, err := client.Post(getBranchPermissionsURI(project, repository), bytes.NewBuffer(request))
 
 	if err != nil {
 		return err
@@ -190,34 +240,70 @@ func resourceBranchPermissionsCreate(d *schema.ResourceData, m interface{}) erro
 
 	_ = d.Set("permission_id", branchPermissionResponse.Id)
 
-	d.SetId(fmt.Sprintf("%s|%s|%s|%s",
+	d.SetId(createBranchPermissionsID(d))
+	return resourceBranchPermissionsRead(d, m)
+}
+
+func resourceBranchPermissionsUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+
+	project := d.Get("project").(string)
+	repository := d.Get("repository").(string)
+	branchPermission := newBranchPermissionPayloadFromResource(d)
+
+	request, err := json.Marshal(branchPermission)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Put(fmt.Sprintf("%s/%d",
+		getBranchPermissionsURI(project, repository),
+		d.Get("permission_id").(int),
+	), bytes.NewBuffer(request))
+
+	if err != nil {
+		return err
+	}
+
+	// ref_pattern and type are part of the ID, so it has to follow in-place changes of either
+	d.SetId(createBranchPermissionsID(d))
+	return resourceBranchPermissionsRead(d, m)
+}
+
+func createBranchPermissionsID(d *schema.ResourceData) string {
+	return fmt.Sprintf("%s|%s|%s|%s|%s",
 		d.Get("project").(string),
 		d.Get("repository").(string),
 		d.Get("ref_pattern").(string),
-		d.Get("type").(string)),
+		d.Get("type").(string),
+		d.Get("matcher_type").(string),
 	)
-	return resourceBranchPermissionsRead(d, m)
 }
 
 func resourceBranchPermissionsRead(d *schema.ResourceData, m interface{}) error {
 	id := d.Id()
 	if id != "" {
+		// IDs without the matcher type are still accepted, they match restrictions of any matcher type
 		parts := strings.Split(id, "|")
-		if len(parts) == 4 {
+		if len(parts) == 4 || len(parts) == 5 {
 			_ = d.Set("project", parts[0])
 			_ = d.Set("repository", parts[1])
 			_ = d.Set("ref_pattern", parts[2])
 			_ = d.Set("type", parts[3])
+			if len(parts) == 5 {
+				_ = d.Set("matcher_type", parts[4])
+			}
 		} else {
-			return fmt.Errorf("incorrect ID format, should match `project|repository|ref_pattern|type`")
+			return fmt.Errorf("incorrect ID format, should match `project|repository|ref_pattern|type|matcher_type` or `project||ref_pattern|type|matcher_type`")
 		}
 	}
 
-	branchPermissionId := d.Get("permission_id")
+	branchPermissionId := d.Get("permission_id").(int)
 
 	var err error
 
-	if branchPermissionId == nil {
+	if branchPermissionId == 0 {
 		err = getBranchPermissionFromList(d, m)
 	} else {
 		err = getBranchPermissionById(d, m)
@@ -227,6 +313,10 @@ func resourceBranchPermissionsRead(d *schema.ResourceData, m interface{}) error
 		return err
 	}
 
+	if d.Id() != "" {
+		d.SetId(createBranchPermissionsID(d))
+	}
+
 	return nil
 }
 
@@ -237,12 +327,17 @@ func getBranchPermissionById(d *schema.ResourceData, m interface{}) error {
 
 	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
 
-	resp, err := client.Get(fmt.Sprintf("/rest/branch-permissions/2.0/projects/%s/repos/%s/restrictions/%d",
-		project,
-		repository,
+	resp, err := client.Get(fmt.Sprintf("%s/%d",
+		getBranchPermissionsURI(project, repository),
 		id,
 	))
 
+	if resp != nil && resp.StatusCode == 404 {
+		log.Printf("[WARN] Branch restriction %d in %s not found, removing from state", id, d.Id())
+		d.SetId("")
+		return nil
+	}
+
 	if err != nil {
 		return err
 	}
@@ -257,23 +352,7 @@ func getBranchPermissionById(d *schema.ResourceData, m interface{}) error {
 		return err
 	}
 
-	_ = d.Set("permission_id", branchPermissionResponse.Id)
-	_ = d.Set("type", branchPermissionResponse.Type)
-	_ = d.Set("exception_groups", branchPermissionResponse.Groups)
-
-	// Convert slice of structs back to slice object for branchPermissionResponse.Users
-	exceptionUsers := make([]string, 0, len(branchPermissionResponse.Users))
-	for _, item := range branchPermissionResponse.Users {
-		exceptionUsers = append(exceptionUsers, item.Name)
-	}
-	_ = d.Set("exception_users", exceptionUsers)
-
-	// Convert slice of structs back to slice object for branchPermissionResponse.Users
-	exceptionAccessKeys := make([]int, 0, len(branchPermissionResponse.AccessKeys))
-	for _, item := range branchPermissionResponse.AccessKeys {
-		exceptionAccessKeys = append(exceptionAccessKeys, item.Key.ID)
-	}
-	_ = d.Set("exception_access_keys", exceptionAccessKeys)
+	setBranchPermissionFromResponse(d, branchPermissionResponse)
 
 	return nil
 }
@@ -281,14 +360,13 @@ func getBranchPermissionById(d *schema.ResourceData, m interface{}) error {
 func getBranchPermissionFromList(d *schema.ResourceData, m interface{}) error {
 	project := d.Get("project").(string)
 	repository := d.Get("repository").(string)
+	refPattern := d.Get("ref_pattern").(string)
 	restrictionType := d.Get("type").(string)
+	matcherType := d.Get("matcher_type").(string)
 
 	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
 
-	resp, err := client.Get(fmt.Sprintf("/rest/branch-permissions/2.0/projects/%s/repos/%s/restrictions",
-		project,
-		repository,
-	))
+	resp, err := client.Get(getBranchPermissionsURI(project, repository))
 
 	if err != nil {
 		return err
@@ -305,38 +383,68 @@ func getBranchPermissionFromList(d *schema.ResourceData, m interface{}) error {
 	}
 
 	for _, item := range allRepositoryBranchPermissionsResponse.Values {
-		if strings.ToLower(strings.Replace(item.Type, "_", "-", -1)) == restrictionType {
-			_ = d.Set("permission_id", item.Id)
-			_ = d.Set("type", item.Type)
-			_ = d.Set("exception_groups", item.Groups)
-
-			// Convert slice of structs back to slice object for exception_users
-			exceptionUsers := make([]string, 0, len(item.Users))
-			for _, item := range item.Users {
-				exceptionUsers = append(exceptionUsers, item.Name)
-			}
-			_ = d.Set("exception_users", exceptionUsers)
-
-			// Convert slice of structs back to slice object for exception_access_keys
-			exceptionAccessKeys := make([]int, 0, len(item.AccessKeys))
-			for _, item := range item.AccessKeys {
-				exceptionAccessKeys = append(exceptionAccessKeys, item.Key.ID)
-			}
-			_ = d.Set("exception_access_keys", exceptionAccessKeys)
+		if matcherType != "" && item.Matcher.Type.Id != matcherType {
+			continue
+		}
 
+		if normalizeBranchPermissionType(item.Type) == restrictionType && item.Matcher.Id == refPattern {
+			setBranchPermissionFromResponse(d, item)
 			return nil
 		}
 	}
 
-	return fmt.Errorf("incorrect ID format, should match `project|repository|ref_pattern`")
+	return fmt.Errorf("no branch restriction of type %s matching %s found, ID should match `project|repository|ref_pattern|type|matcher_type`", restrictionType, refPattern)
+}
+
+func normalizeBranchPermissionType(restrictionType string) string {
+	return strings.ToLower(strings.Replace(restrictionType, "_", "-", -1))
+}
+
+func setBranchPermissionFromResponse(d *schema.ResourceData, item BranchPermissionResponse) {
+	_ = d.Set("permission_id", item.Id)
+	_ = d.Set("type", normalizeBranchPermissionType(item.Type))
+	if item.Matcher.Id != "" {
+		_ = d.Set("ref_pattern", item.Matcher.Id)
+	}
+	if item.Matcher.Type.Id != "" {
+		_ = d.Set("matcher_type", item.Matcher.Type.Id)
+	}
+	_ = d.Set("exception_groups", item.Groups)
+
+	// Convert slice of structs back to slice object for exception_users
+	exceptionUsers := make([]string, 0, len(item.Users))
+	for _, user := range item.Users {
+		exceptionUsers = append(exceptionUsers, user.Name)
+	}
+	_ = d.Set("exception_users", exceptionUsers)
+
+	// Convert slice of structs back to slice object for exception_access_keys
+	exceptionAccessKeys := make([]string, 0, len(item.AccessKeys))
+	for _, accessKey := range item.AccessKeys {
+		exceptionAccessKeys = append(exceptionAccessKeys, strconv.Itoa(accessKey.Key.ID))
+	}
+	_ = d.Set("exception_access_keys", exceptionAccessKeys)
 }
 
 func resourceBranchPermissionsDelete(d *schema.ResourceData, m interface{}) error {
 	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
-	_, err := client.Delete(fmt.Sprintf("/rest/branch-permissions/2.0/projects/%s/repos/%s/restrictions/%d",
-		d.Get("project").(string),
-		d.Get("repository").(string),
+	_, err := client.Delete(fmt.Sprintf("%s/%d",
+		getBranchPermissionsURI(d.Get("project").(string), d.Get("repository").(string)),
 		d.Get("permission_id").(int)))
 
 	return err
 }
+
+// getBranchPermissionsURI returns the restrictions endpoint of the repository, or of the project if repository is empty.
+func getBranchPermissionsURI(project string, repository string) string {
+	if repository == "" {
+		return fmt.Sprintf("/rest/branch-permissions/2.0/projects/%s/restrictions",
+			url.PathEscape(project),
+		)
+	}
+
+	return fmt.Sprintf("/rest/branch-permissions/2.0/projects/%s/repos/%s/restrictions",
+		url.PathEscape(project),
+		url.PathEscape(repository),
+	)
+}
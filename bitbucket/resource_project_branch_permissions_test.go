@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccBitbucketResourceBranchPermission_requiredArgumentsOnly(t *testing.T) {
@@ -29,7 +30,7 @@ func TestAccBitbucketResourceBranchPermission_requiredArgumentsOnly(t *testing.T
 			{
 				Config: config,
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr(resourceName, "id", fmt.Sprintf("%v|repo|refs/heads/master|pull-request-only", projectKey)),
+					resource.TestCheckResourceAttr(resourceName, "id", fmt.Sprintf("%v|repo|refs/heads/master|pull-request-only|PATTERN", projectKey)),
 					resource.TestCheckResourceAttr(resourceName, "project", projectKey),
 					resource.TestCheckResourceAttr(resourceName, "repository", "repo"),
 					resource.TestCheckResourceAttr(resourceName, "ref_pattern", "refs/heads/master"),
@@ -81,17 +82,17 @@ func TestAccBitbucketResourceBranchPermission_allArguments(t *testing.T) {
 			{
 				Config: config,
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr(resourceName, "id", fmt.Sprintf("%v|repo|refs/heads/master|pull-request-only", projectKey)),
+					resource.TestCheckResourceAttr(resourceName, "id", fmt.Sprintf("%v|repo|refs/heads/master|pull-request-only|PATTERN", projectKey)),
 					resource.TestCheckResourceAttr(resourceName, "project", projectKey),
 					resource.TestCheckResourceAttr(resourceName, "repository", "repo"),
 					resource.TestCheckResourceAttr(resourceName, "ref_pattern", "refs/heads/master"),
 					resource.TestCheckResourceAttr(resourceName, "type", "pull-request-only"),
 					resource.TestCheckResourceAttrSet(resourceName, "permission_id"),
 					resource.TestCheckResourceAttr(resourceName, "exception_users.#", "1"),
-					resource.TestCheckResourceAttr(resourceName, "exception_users.0", "admin"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "exception_users.*", "admin"),
 					resource.TestCheckResourceAttr(resourceName, "exception_groups.#", "2"),
-					resource.TestCheckResourceAttr(resourceName, "exception_groups.0", fmt.Sprintf("test-project-%s", projectKey)),
-					resource.TestCheckResourceAttr(resourceName, "exception_groups.1", fmt.Sprintf("test-project-%s-2", projectKey)),
+					resource.TestCheckTypeSetElemAttr(resourceName, "exception_groups.*", fmt.Sprintf("test-project-%s", projectKey)),
+					resource.TestCheckTypeSetElemAttr(resourceName, "exception_groups.*", fmt.Sprintf("test-project-%s-2", projectKey)),
 
 					resource.TestCheckResourceAttr(resourceName2, "type", "no-deletes"),
 					resource.TestCheckResourceAttrSet(resourceName2, "permission_id"),
@@ -100,3 +101,133 @@ func TestAccBitbucketResourceBranchPermission_allArguments(t *testing.T) {
 		},
 	})
 }
+
+func TestAccBitbucketResourceBranchPermission_projectLevel(t *testing.T) {
+	projectKey := fmt.Sprintf("TEST%v", rand.New(rand.NewSource(time.Now().UnixNano())).Int())
+
+	config := fmt.Sprintf(`
+	resource "bitbucketserver_project" "test" {
+		key  = "%v"
+		name = "test-project-%v"
+	}
+
+	resource "bitbucketserver_project_branch_permissions" "test" {
+		project     = bitbucketserver_project.test.key
+		ref_pattern = "refs/heads/main"
+		type        = "no-deletes"
+	}`, projectKey, projectKey)
+
+	resourceName := "bitbucketserver_project_branch_permissions.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", fmt.Sprintf("%v||refs/heads/main|no-deletes|PATTERN", projectKey)),
+					resource.TestCheckResourceAttr(resourceName, "project", projectKey),
+					resource.TestCheckResourceAttr(resourceName, "repository", ""),
+					resource.TestCheckResourceAttr(resourceName, "type", "no-deletes"),
+					resource.TestCheckResourceAttrSet(resourceName, "permission_id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccBitbucketResourceBranchPermission_import(t *testing.T) {
+	projectKey := fmt.Sprintf("TEST%v", rand.New(rand.NewSource(time.Now().UnixNano())).Int())
+
+	config := baseConfigForRepositoryBasedTests(projectKey) + `
+	resource "bitbucketserver_project_branch_permissions" "test" {
+		project      = bitbucketserver_project.test.key
+		repository   = bitbucketserver_repository.test.slug
+		ref_pattern  = "refs/heads/master"
+		matcher_type = "BRANCH"
+		type         = "fast-forward-only"
+	}`
+
+	resourceName := "bitbucketserver_project_branch_permissions.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", fmt.Sprintf("%v|repo|refs/heads/master|fast-forward-only|BRANCH", projectKey)),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("%v|repo|refs/heads/master|fast-forward-only|BRANCH", projectKey),
+				ImportStateVerify: true,
+			},
+			{
+				// IDs without the matcher type are still accepted
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("%v|repo|refs/heads/master|fast-forward-only", projectKey),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccBitbucketResourceBranchPermission_updateInPlace(t *testing.T) {
+	projectKey := fmt.Sprintf("TEST%v", rand.New(rand.NewSource(time.Now().UnixNano())).Int())
+
+	configTemplate := baseConfigForRepositoryBasedTests(projectKey) + `
+	resource "bitbucketserver_project_branch_permissions" "test" {
+		project         = bitbucketserver_project.test.key
+		repository      = bitbucketserver_repository.test.slug
+		ref_pattern     = "%s"
+		matcher_type    = "%s"
+		type            = "pull-request-only"
+		exception_users = %s
+	}`
+
+	config := fmt.Sprintf(configTemplate, "refs/heads/master", "BRANCH", `["admin"]`)
+	configUpdated := fmt.Sprintf(configTemplate, "RELEASE", "MODEL_CATEGORY", `[]`)
+
+	resourceName := "bitbucketserver_project_branch_permissions.test"
+	var permissionId string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "ref_pattern", "refs/heads/master"),
+					resource.TestCheckResourceAttr(resourceName, "matcher_type", "BRANCH"),
+					resource.TestCheckResourceAttr(resourceName, "exception_users.#", "1"),
+					func(s *terraform.State) error {
+						permissionId = s.RootModule().Resources[resourceName].Primary.Attributes["permission_id"]
+						return nil
+					},
+				),
+			},
+			{
+				Config: configUpdated,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", fmt.Sprintf("%v|repo|RELEASE|pull-request-only|MODEL_CATEGORY", projectKey)),
+					resource.TestCheckResourceAttr(resourceName, "ref_pattern", "RELEASE"),
+					resource.TestCheckResourceAttr(resourceName, "matcher_type", "MODEL_CATEGORY"),
+					resource.TestCheckResourceAttr(resourceName, "exception_users.#", "0"),
+					func(s *terraform.State) error {
+						if id := s.RootModule().Resources[resourceName].Primary.Attributes["permission_id"]; id != permissionId {
+							return fmt.Errorf("expected restriction %s to be updated in place, got %s", permissionId, id)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
@@ -1,6 +1,7 @@
 package bitbucket
 
 import (
+	"bytes"
 	"fmt"
 	bitbucketTypes "github.com/xvlcwk-terraform/terraform-provider-bitbucketserver/bitbucket/util/types"
 	"math/rand"
@@ -50,6 +51,43 @@ func TestAccBitbucketProject(t *testing.T) {
 	})
 }
 
+func TestAccBitbucketProject_forceDestroy(t *testing.T) {
+	projectKey := fmt.Sprintf("TEST%v", rand.New(rand.NewSource(time.Now().UnixNano())).Int())
+	config := fmt.Sprintf(`
+		resource "bitbucketserver_project" "test" {
+			key = "%v"
+			name = "test-project-for-force-destroy"
+			force_destroy = true
+		}
+	`, projectKey)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBitbucketProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckBitbucketProjectExists("bitbucketserver_project.test"),
+					resource.TestCheckResourceAttr("bitbucketserver_project.test", "force_destroy", "true"),
+					testAccCreateUnmanagedRepository(projectKey, "unmanaged-repository"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCreateUnmanagedRepository creates a repository outside of terraform, which blocks the deletion of its project.
+func testAccCreateUnmanagedRepository(project string, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+		_, err := client.Post(fmt.Sprintf("/rest/api/1.0/projects/%s/repos", project),
+			bytes.NewBufferString(fmt.Sprintf(`{"name": "%s", "scmId": "git"}`, name)))
+		return err
+	}
+}
+
 func testAccCheckBitbucketProjectDestroy(s *terraform.State) error {
 	client := testAccProvider.Meta().(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
 	rs, ok := s.RootModule().Resources["bitbucketserver_project.test"]
@@ -0,0 +1,69 @@
+package bitbucket
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"strings"
+)
+
+func resourceProjectWebhook() *schema.Resource {
+	// a project webhook has the same attributes as a repository webhook, it just isn't bound to a repository
+	webhookSchema := resourceRepositoryWebhook().Schema
+	delete(webhookSchema, "repository")
+
+	return &schema.Resource{
+		Create:        resourceProjectWebhookCreate,
+		Update:        resourceProjectWebhookUpdate,
+		Read:          resourceProjectWebhookRead,
+		Delete:        resourceProjectWebhookDelete,
+		CustomizeDiff: validateWebhookEventsVersion,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: webhookSchema,
+	}
+}
+
+func resourceProjectWebhookUpdate(d *schema.ResourceData, m interface{}) error {
+	err := updateWebhook(d, m, getWebhooksURI(d.Get("project").(string), ""))
+	if err != nil {
+		return err
+	}
+
+	return resourceProjectWebhookRead(d, m)
+}
+
+func resourceProjectWebhookCreate(d *schema.ResourceData, m interface{}) error {
+	err := createWebhook(d, m, getWebhooksURI(d.Get("project").(string), ""))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", d.Get("project").(string), d.Get("name").(string)))
+	return resourceProjectWebhookRead(d, m)
+}
+
+func resourceProjectWebhookRead(d *schema.ResourceData, m interface{}) error {
+	id := d.Id()
+	if id != "" {
+		parts := strings.Split(id, "/")
+		if len(parts) == 2 {
+			_ = d.Set("project", parts[0])
+			_ = d.Set("name", parts[1])
+		} else {
+			return fmt.Errorf("incorrect ID format, should match `project/name`")
+		}
+	}
+
+	err := readWebhook(d, m, getWebhooksURI(d.Get("project").(string), ""))
+	if err != nil {
+		return fmt.Errorf("%v, ID should match `project/name`", err)
+	}
+
+	return nil
+}
+
+func resourceProjectWebhookDelete(d *schema.ResourceData, m interface{}) error {
+	return deleteWebhook(d, m, getWebhooksURI(d.Get("project").(string), ""))
+}
@@ -0,0 +1,56 @@
+package bitbucket
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccBitbucketResourceProjectWebhook_simple(t *testing.T) {
+	projectKey := fmt.Sprintf("TEST%v", rand.New(rand.NewSource(time.Now().UnixNano())).Int())
+
+	configString := `
+		resource "bitbucketserver_project" "test" {
+			key  = "%v"
+			name = "test-project-%v"
+		}
+
+		resource "bitbucketserver_project_webhook" "test" {
+			project     = bitbucketserver_project.test.key
+			name        = "google"
+			webhook_url = "%v"
+			secret      = "abc"
+			events      = ["repo:refs_changed"]
+			active      = true
+		}
+	`
+
+	config := fmt.Sprintf(configString, projectKey, projectKey, "https://www.oldurl.com/")
+	newConfig := fmt.Sprintf(configString, projectKey, projectKey, "https://www.newurl.com/")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucketserver_project_webhook.test", "id", projectKey+"/google"),
+					resource.TestCheckResourceAttr("bitbucketserver_project_webhook.test", "project", projectKey),
+					resource.TestCheckResourceAttr("bitbucketserver_project_webhook.test", "webhook_url", "https://www.oldurl.com/"),
+					resource.TestCheckResourceAttr("bitbucketserver_project_webhook.test", "secret", "abc"),
+					resource.TestCheckResourceAttrSet("bitbucketserver_project_webhook.test", "webhook_id"),
+				),
+			},
+			{
+				Config: newConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucketserver_project_webhook.test", "webhook_url", "https://www.newurl.com/"),
+				),
+			},
+		},
+	})
+}
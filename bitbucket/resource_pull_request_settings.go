@@ -0,0 +1,293 @@
+package bitbucket
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	bitbucketTypes "github.com/xvlcwk-terraform/terraform-provider-bitbucketserver/bitbucket/util/types"
+	"log"
+	"net/url"
+	"strings"
+)
+
+type MergeStrategy struct {
+	Id      string `json:"id"`
+	Enabled *bool  `json:"enabled,omitempty"`
+}
+
+type MergeConfig struct {
+	Type            string          `json:"type,omitempty"`
+	DefaultStrategy *MergeStrategy  `json:"defaultStrategy,omitempty"`
+	Strategies      []MergeStrategy `json:"strategies,omitempty"`
+}
+
+type PullRequestSettings struct {
+	// MergeConfig is deliberately not omitted when nil, sending null lets the scope inherit its merge strategies
+	MergeConfig              *MergeConfig `json:"mergeConfig"`
+	RequiredApprovers        int          `json:"requiredApprovers"`
+	RequiredAllTasksComplete bool         `json:"requiredAllTasksComplete"`
+	RequiredSuccessfulBuilds int          `json:"requiredSuccessfulBuilds"`
+	UnapproveOnUpdate        bool         `json:"unapproveOnUpdate"`
+}
+
+var validMergeStrategies = []string{
+	"no-ff", "ff", "ff-only", "rebase-no-ff", "rebase-ff-only", "squash", "squash-ff-only",
+}
+
+func resourcePullRequestSettings() *schema.Resource {
+	return &schema.Resource{
+		Create:        resourcePullRequestSettingsCreate,
+		Read:          resourcePullRequestSettingsRead,
+		Update:        resourcePullRequestSettingsCreate,
+		Delete:        resourcePullRequestSettingsDelete,
+		CustomizeDiff: resourcePullRequestSettingsCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"repository": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Repository slug. If empty, the pull request settings of the project are managed.",
+			},
+			"inherit_merge_strategies": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the merge strategies are inherited from the project, or from the global settings for a project.",
+			},
+			"merge_strategies": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(validMergeStrategies, false),
+				},
+				Set:         schema.HashString,
+				Description: fmt.Sprintf("The enabled merge strategies. Must be any of %v.", validMergeStrategies),
+			},
+			"default_merge_strategy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"merge_strategies"},
+				ValidateFunc: validation.StringInSlice(validMergeStrategies, false),
+				Description:  "The merge strategy selected by default. Must be one of merge_strategies.",
+			},
+			"required_approvers": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"required_all_tasks_complete": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"required_successful_builds": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"unapprove_on_new_commits": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		},
+	}
+}
+
+func getPullRequestSettingsURI(project string, repository string) string {
+	if repository == "" {
+		return fmt.Sprintf("/rest/api/latest/projects/%s/settings/pull-requests/git",
+			url.PathEscape(project),
+		)
+	}
+
+	return fmt.Sprintf("/rest/api/latest/projects/%s/repos/%s/settings/pull-requests",
+		url.PathEscape(project),
+		url.PathEscape(repository),
+	)
+}
+
+func resourcePullRequestSettingsCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	defaultStrategy := d.Get("default_merge_strategy").(string)
+	strategies := d.Get("merge_strategies").(*schema.Set)
+
+	if !d.NewValueKnown("merge_strategies") || !d.NewValueKnown("default_merge_strategy") {
+		return nil
+	}
+
+	// without own strategies Bitbucket falls back to inheriting them, so the flag has to match the strategies
+	inherit := d.GetRawConfig().GetAttr("inherit_merge_strategies")
+	if inherit.IsKnown() && !inherit.IsNull() {
+		if inherit.True() && strategies.Len() > 0 {
+			return fmt.Errorf("merge_strategies and default_merge_strategy can't be set when inherit_merge_strategies is true")
+		}
+
+		if inherit.False() && strategies.Len() == 0 {
+			return fmt.Errorf("merge_strategies and default_merge_strategy are required when inherit_merge_strategies is false")
+		}
+	} else if inherit.IsNull() {
+		if err := d.SetNew("inherit_merge_strategies", strategies.Len() == 0); err != nil {
+			return err
+		}
+	}
+
+	if strategies.Len() > 0 && defaultStrategy == "" {
+		return fmt.Errorf("default_merge_strategy is required when merge_strategies is set")
+	}
+
+	if defaultStrategy != "" && !strategies.Contains(defaultStrategy) {
+		return fmt.Errorf("default_merge_strategy %s must be one of merge_strategies %v", defaultStrategy, strategies.List())
+	}
+
+	return nil
+}
+
+func newPullRequestSettingsFromResource(d *schema.ResourceData) *PullRequestSettings {
+	settings := &PullRequestSettings{
+		RequiredApprovers:        d.Get("required_approvers").(int),
+		RequiredAllTasksComplete: d.Get("required_all_tasks_complete").(bool),
+		RequiredSuccessfulBuilds: d.Get("required_successful_builds").(int),
+		UnapproveOnUpdate:        d.Get("unapprove_on_new_commits").(bool),
+	}
+
+	strategies := d.Get("merge_strategies").(*schema.Set)
+	if !d.Get("inherit_merge_strategies").(bool) && strategies.Len() > 0 {
+		settings.MergeConfig = &MergeConfig{
+			DefaultStrategy: &MergeStrategy{
+				Id: d.Get("default_merge_strategy").(string),
+			},
+		}
+
+		for _, strategy := range strategies.List() {
+			settings.MergeConfig.Strategies = append(settings.MergeConfig.Strategies, MergeStrategy{
+				Id: strategy.(string),
+			})
+		}
+	}
+
+	return settings
+}
+
+func resourcePullRequestSettingsCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+
+	project := d.Get("project").(string)
+	repository := d.Get("repository").(string)
+
+	bytedata, err := json.Marshal(newPullRequestSettingsFromResource(d))
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Post(getPullRequestSettingsURI(project, repository), bytes.NewBuffer(bytedata))
+	if err != nil {
+		return err
+	}
+
+	if repository == "" {
+		d.SetId(project)
+	} else {
+		d.SetId(fmt.Sprintf("%s/%s", project, repository))
+	}
+
+	return resourcePullRequestSettingsRead(d, m)
+}
+
+func resourcePullRequestSettingsRead(d *schema.ResourceData, m interface{}) error {
+	id := d.Id()
+	if id != "" {
+		parts := strings.Split(id, "/")
+		if len(parts) == 1 {
+			_ = d.Set("project", parts[0])
+		} else if len(parts) == 2 {
+			_ = d.Set("project", parts[0])
+			_ = d.Set("repository", parts[1])
+		} else {
+			return fmt.Errorf("incorrect ID format, should match `project` or `project/repository`")
+		}
+	}
+
+	project := d.Get("project").(string)
+	repository := d.Get("repository").(string)
+
+	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+	resp, err := client.Get(getPullRequestSettingsURI(project, repository))
+	if resp != nil && resp.StatusCode == 404 {
+		log.Printf("[WARN] Pull request settings %s not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	var settings PullRequestSettings
+
+	decoder := json.NewDecoder(resp.Body)
+	err = decoder.Decode(&settings)
+	if err != nil {
+		return err
+	}
+
+	_ = d.Set("required_approvers", settings.RequiredApprovers)
+	_ = d.Set("required_all_tasks_complete", settings.RequiredAllTasksComplete)
+	_ = d.Set("required_successful_builds", settings.RequiredSuccessfulBuilds)
+	_ = d.Set("unapprove_on_new_commits", settings.UnapproveOnUpdate)
+
+	scope := "PROJECT"
+	if repository != "" {
+		scope = "REPOSITORY"
+	}
+
+	if settings.MergeConfig == nil || settings.MergeConfig.Type != scope {
+		_ = d.Set("inherit_merge_strategies", true)
+		_ = d.Set("merge_strategies", nil)
+		_ = d.Set("default_merge_strategy", "")
+		return nil
+	}
+
+	var strategies []string
+	for _, strategy := range settings.MergeConfig.Strategies {
+		if strategy.Enabled == nil || *strategy.Enabled {
+			strategies = append(strategies, strategy.Id)
+		}
+	}
+
+	_ = d.Set("inherit_merge_strategies", false)
+	_ = d.Set("merge_strategies", strategies)
+	if settings.MergeConfig.DefaultStrategy != nil {
+		_ = d.Set("default_merge_strategy", settings.MergeConfig.DefaultStrategy.Id)
+	}
+
+	return nil
+}
+
+func resourcePullRequestSettingsDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+
+	// there is no delete for the settings, so they are reset to inherit the merge strategies and disable all merge checks
+	bytedata, err := json.Marshal(&PullRequestSettings{})
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Post(getPullRequestSettingsURI(d.Get("project").(string), d.Get("repository").(string)), bytes.NewBuffer(bytedata))
+
+	return err
+}
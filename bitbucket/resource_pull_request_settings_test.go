@@ -0,0 +1,115 @@
+package bitbucket
+
+import (
+	"fmt"
+	"math/rand"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccBitbucketResourcePullRequestSettings_repository(t *testing.T) {
+	projectKey := fmt.Sprintf("TEST%v", rand.New(rand.NewSource(time.Now().UnixNano())).Int())
+
+	config := baseConfigForRepositoryBasedTests(projectKey) + `
+		resource "bitbucketserver_pull_request_settings" "test" {
+			project                     = bitbucketserver_project.test.key
+			repository                  = bitbucketserver_repository.test.slug
+			inherit_merge_strategies    = false
+			merge_strategies            = ["no-ff", "squash"]
+			default_merge_strategy      = "squash"
+			required_approvers          = 2
+			required_all_tasks_complete = true
+			required_successful_builds  = 1
+			unapprove_on_new_commits    = true
+		}
+	`
+
+	configNotInheritingWithoutStrategies := baseConfigForRepositoryBasedTests(projectKey) + `
+		resource "bitbucketserver_pull_request_settings" "test" {
+			project                  = bitbucketserver_project.test.key
+			repository               = bitbucketserver_repository.test.slug
+			inherit_merge_strategies = false
+		}
+	`
+
+	configInheritingWithStrategies := baseConfigForRepositoryBasedTests(projectKey) + `
+		resource "bitbucketserver_pull_request_settings" "test" {
+			project                  = bitbucketserver_project.test.key
+			repository               = bitbucketserver_repository.test.slug
+			inherit_merge_strategies = true
+			merge_strategies         = ["no-ff"]
+			default_merge_strategy   = "no-ff"
+		}
+	`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      configInheritingWithStrategies,
+				ExpectError: regexp.MustCompile("can't be set when inherit_merge_strategies is true"),
+			},
+			{
+				Config:      configNotInheritingWithoutStrategies,
+				ExpectError: regexp.MustCompile("merge_strategies and default_merge_strategy are required"),
+			},
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucketserver_pull_request_settings.test", "id", fmt.Sprintf("%v/repo", projectKey)),
+					resource.TestCheckResourceAttr("bitbucketserver_pull_request_settings.test", "inherit_merge_strategies", "false"),
+					resource.TestCheckResourceAttr("bitbucketserver_pull_request_settings.test", "merge_strategies.#", "2"),
+					resource.TestCheckTypeSetElemAttr("bitbucketserver_pull_request_settings.test", "merge_strategies.*", "no-ff"),
+					resource.TestCheckTypeSetElemAttr("bitbucketserver_pull_request_settings.test", "merge_strategies.*", "squash"),
+					resource.TestCheckResourceAttr("bitbucketserver_pull_request_settings.test", "default_merge_strategy", "squash"),
+					resource.TestCheckResourceAttr("bitbucketserver_pull_request_settings.test", "required_approvers", "2"),
+					resource.TestCheckResourceAttr("bitbucketserver_pull_request_settings.test", "required_all_tasks_complete", "true"),
+					resource.TestCheckResourceAttr("bitbucketserver_pull_request_settings.test", "required_successful_builds", "1"),
+					resource.TestCheckResourceAttr("bitbucketserver_pull_request_settings.test", "unapprove_on_new_commits", "true"),
+				),
+			},
+			{
+				ResourceName:      "bitbucketserver_pull_request_settings.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccBitbucketResourcePullRequestSettings_projectInherit(t *testing.T) {
+	projectKey := fmt.Sprintf("TEST%v", rand.New(rand.NewSource(time.Now().UnixNano())).Int())
+
+	config := fmt.Sprintf(`
+		resource "bitbucketserver_project" "test" {
+			key  = "%v"
+			name = "test-project-%v"
+		}
+
+		resource "bitbucketserver_pull_request_settings" "test" {
+			project                  = bitbucketserver_project.test.key
+			inherit_merge_strategies = true
+			required_approvers       = 1
+		}
+	`, projectKey, projectKey)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucketserver_pull_request_settings.test", "id", projectKey),
+					resource.TestCheckResourceAttr("bitbucketserver_pull_request_settings.test", "inherit_merge_strategies", "true"),
+					resource.TestCheckResourceAttr("bitbucketserver_pull_request_settings.test", "merge_strategies.#", "0"),
+					resource.TestCheckResourceAttr("bitbucketserver_pull_request_settings.test", "required_approvers", "1"),
+				),
+			},
+		},
+	})
+}
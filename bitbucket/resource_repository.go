@@ -2,14 +2,20 @@ package bitbucket
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/xvlcwk-terraform/terraform-provider-bitbucketserver/bitbucket/util/client"
 	bitbucketTypes "github.com/xvlcwk-terraform/terraform-provider-bitbucketserver/bitbucket/util/types"
 	"io/ioutil"
+	"net/url"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"strings"
+	"time"
 )
 
 type CloneUrl struct {
@@ -18,16 +24,27 @@ type CloneUrl struct {
 }
 
 type Repository struct {
-	Name        string `json:"name,omitempty"`
-	Slug        string `json:"slug,omitempty"`
-	Description string `json:"description,omitempty"`
-	Forkable    bool   `json:"forkable"`
-	Public      bool   `json:"public,omitempty"`
+	Name        string                 `json:"name,omitempty"`
+	Slug        string                 `json:"slug,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Forkable    bool                   `json:"forkable"`
+	Public      bool                   `json:"public,omitempty"`
+	Project     *RepositoryForkProject `json:"project,omitempty"`
+	Archived    *bool                  `json:"archived,omitempty"`
 	Links       struct {
 		Clone []CloneUrl `json:"clone,omitempty"`
 	} `json:"links,omitempty"`
 }
 
+type RepositoryDefaultBranch struct {
+	ID        string `json:"id,omitempty"`
+	DisplayID string `json:"displayId,omitempty"`
+}
+
+type RepositoryLabel struct {
+	Name string `json:"name,omitempty"`
+}
+
 type RepositoryForkProject struct {
 	Key string `json:"key,omitempty"`
 }
@@ -39,20 +56,24 @@ type RepositoryFork struct {
 
 func resourceRepository() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceRepositoryCreate,
-		Update: resourceRepositoryUpdate,
-		Read:   resourceRepositoryRead,
-		Exists: resourceRepositoryExists,
-		Delete: resourceRepositoryDelete,
+		Create:        resourceRepositoryCreate,
+		Update:        resourceRepositoryUpdate,
+		Read:          resourceRepositoryRead,
+		Exists:        resourceRepositoryExists,
+		DeleteContext: resourceRepositoryDelete,
+		CustomizeDiff: resourceRepositoryCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"name": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the repository. Renaming the repository changes its slug.",
 			},
 			"slug": {
 				Type:     schema.TypeString,
@@ -60,9 +81,9 @@ func resourceRepository() *schema.Resource {
 				Computed: true,
 			},
 			"project": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Key of the project of the repository. Changing it moves the repository.",
 			},
 			"description": {
 				Type:     schema.TypeString,
@@ -93,6 +114,68 @@ func resourceRepository() *schema.Resource {
 				Optional: true,
 				Default:  false,
 			},
+			"labels": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "Labels of the repository. Labels not listed are removed.",
+			},
+			"archived": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Archive the repository, making it read-only. Requires Bitbucket 8.0 or later.",
+			},
+			"destroy_behavior": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "delete",
+				ValidateFunc: validation.StringInSlice([]string{"archive", "delete"}, false),
+				Description:  "Whether destroying the resource deletes the repository or only archives it.",
+			},
+			"default_branch": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Name of the default branch of the repository.",
+			},
+			"initialize": {
+				Type:             schema.TypeList,
+				Optional:         true,
+				MaxItems:         1,
+				ConflictsWith:    []string{"fork_repository_project"},
+				Description:      "Files committed to the default branch when the repository is created. Changes after the creation are ignored.",
+				DiffSuppressFunc: suppressDiffAfterCreation,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"readme": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							DiffSuppressFunc: suppressDiffAfterCreation,
+							Description:      "Content of the README.md.",
+						},
+						"gitignore": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							DiffSuppressFunc: suppressDiffAfterCreation,
+							Description:      "Content of the .gitignore.",
+						},
+						"codeowners": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							DiffSuppressFunc: suppressDiffAfterCreation,
+							Description:      "Content of the .bitbucket/CODEOWNERS.",
+						},
+						"commit_message": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							Default:          "Initial commit",
+							DiffSuppressFunc: suppressDiffAfterCreation,
+						},
+					},
+				},
+			},
 			"clone_ssh": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -114,6 +197,11 @@ func newRepositoryFromResource(d *schema.ResourceData) (Repo *Repository) {
 		Public:      d.Get("public").(bool),
 	}
 
+	// only sent when needed, Bitbucket versions before 8.0 don't support archiving
+	if archived := d.Get("archived").(bool); archived || d.HasChange("archived") {
+		repo.Archived = &archived
+	}
+
 	return repo
 }
 
@@ -122,16 +210,39 @@ func resourceRepositoryUpdate(d *schema.ResourceData, m interface{}) error {
 	project := d.Get("project").(string)
 	repo := newRepositoryFromResource(d)
 
+	repoSlug := determineSlug(d)
+	currentProject := project
+
+	// a rename or move is sent to the current location of the repository
+	moved := !d.IsNewResource() && (d.HasChange("name") || d.HasChange("project"))
+	if moved {
+		oldProject, _ := d.GetChange("project")
+		oldName, _ := d.GetChange("name")
+		oldSlug, _ := d.GetChange("slug")
+
+		currentProject = oldProject.(string)
+		repoSlug = oldSlug.(string)
+		if repoSlug == "" {
+			repoSlug = oldName.(string)
+		}
+
+		// the new slug is derived by Bitbucket from the new name
+		repo.Slug = ""
+		if d.HasChange("project") {
+			repo.Project = &RepositoryForkProject{
+				Key: project,
+			}
+		}
+	}
+
 	bytedata, err := json.Marshal(repo)
 
 	if err != nil {
 		return err
 	}
 
-	repoSlug := determineSlug(d)
-
-	_, err = client.Put(fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s",
-		project,
+	resp, err := client.Put(fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s",
+		currentProject,
 		repoSlug,
 	), bytes.NewBuffer(bytedata))
 
@@ -139,14 +250,69 @@ func resourceRepositoryUpdate(d *schema.ResourceData, m interface{}) error {
 		return err
 	}
 
+	if moved {
+		var updatedRepo Repository
+
+		decoder := json.NewDecoder(resp.Body)
+		err = decoder.Decode(&updatedRepo)
+		if err != nil {
+			return err
+		}
+
+		repoSlug = updatedRepo.Slug
+		_ = d.Set("slug", repoSlug)
+		d.SetId(fmt.Sprintf("%s/%s", project, repoSlug))
+	}
+
 	err = handleRepositoryGitLFSChanges(client, project, repoSlug, d)
 	if err != nil {
 		return err
 	}
 
+	err = handleRepositoryLabelChanges(client, project, repoSlug, d)
+	if err != nil {
+		return err
+	}
+
+	err = handleRepositoryDefaultBranchChanges(client, project, repoSlug, d)
+	if err != nil {
+		return err
+	}
+
 	return resourceRepositoryRead(d, m)
 }
 
+// resourceRepositoryCustomizeDiff recomputes the slug and clone urls when a repository is renamed or moved,
+// so the change shows in the plan for the repository and every resource referring to them. The SDK can't
+// attach warnings to a plan, the unknown slug is the only notice dependent resources get.
+func resourceRepositoryCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || (!d.HasChange("name") && !d.HasChange("project")) {
+		return nil
+	}
+
+	oldProject, _ := d.GetChange("project")
+	oldName, _ := d.GetChange("name")
+
+	// Bitbucket derives the new slug from the new name, a configured slug would no longer match the repository
+	if d.HasChange("name") {
+		if !d.GetRawConfig().GetAttr("slug").IsNull() {
+			return fmt.Errorf("repository %s/%s can't be renamed while slug is configured, remove slug from the configuration", oldProject, oldName)
+		}
+
+		err := d.SetNewComputed("slug")
+		if err != nil {
+			return err
+		}
+	}
+
+	err := d.SetNewComputed("clone_ssh")
+	if err != nil {
+		return err
+	}
+
+	return d.SetNewComputed("clone_https")
+}
+
 func resourceRepositoryCreate(d *schema.ResourceData, m interface{}) error {
 	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
 
@@ -179,6 +345,30 @@ func resourceRepositoryCreate(d *schema.ResourceData, m interface{}) error {
 		return err
 	}
 
+	err = handleRepositoryLabelChanges(client, project, repoSlug, d)
+	if err != nil {
+		return err
+	}
+
+	if forkProject == "" {
+		err = handleRepositoryDefaultBranchChanges(client, project, repoSlug, d)
+		if err != nil {
+			return err
+		}
+
+		err = initializeRepository(client, project, repoSlug, d)
+		if err != nil {
+			return err
+		}
+	}
+
+	if forkProject == "" && d.Get("archived").(bool) {
+		err = archiveRepository(client, project, repoSlug)
+		if err != nil {
+			return err
+		}
+	}
+
 	if forkProject != "" {
 		// after forking a repository, run the update loop to update any names/descriptions etc of the forked repo
 		return resourceRepositoryUpdate(d, m)
@@ -189,6 +379,8 @@ func resourceRepositoryCreate(d *schema.ResourceData, m interface{}) error {
 
 func createNewRepository(client *client.BitbucketClient, d *schema.ResourceData, project string) error {
 	repo := newRepositoryFromResource(d)
+	// a repository can't be created archived, it is archived after its setup
+	repo.Archived = nil
 	bytedata, err := json.Marshal(repo)
 
 	if err != nil {
@@ -254,6 +446,169 @@ func handleRepositoryGitLFSChanges(client *client.BitbucketClient, project strin
 	return nil
 }
 
+func getRepositoryLabelsURI(project string, repoSlug string) string {
+	return fmt.Sprintf("/rest/api/latest/projects/%s/repos/%s/labels",
+		url.PathEscape(project),
+		url.PathEscape(repoSlug),
+	)
+}
+
+func handleRepositoryLabelChanges(client *client.BitbucketClient, project string, repoSlug string, d *schema.ResourceData) error {
+	if !d.HasChange("labels") {
+		return nil
+	}
+
+	existing, err := readRepositoryLabels(client, project, repoSlug)
+	if err != nil {
+		return err
+	}
+
+	desired := d.Get("labels").(*schema.Set)
+	current := schema.NewSet(schema.HashString, nil)
+	for _, label := range existing {
+		current.Add(label)
+	}
+
+	for _, label := range desired.Difference(current).List() {
+		bytedata, err := json.Marshal(&RepositoryLabel{Name: label.(string)})
+		if err != nil {
+			return err
+		}
+
+		_, err = client.Post(getRepositoryLabelsURI(project, repoSlug), bytes.NewBuffer(bytedata))
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, label := range current.Difference(desired).List() {
+		_, err = client.Delete(fmt.Sprintf("%s/%s",
+			getRepositoryLabelsURI(project, repoSlug),
+			url.PathEscape(label.(string)),
+		))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func readRepositoryLabels(client *client.BitbucketClient, project string, repoSlug string) ([]string, error) {
+	repositoryLabels, err := readAllPages[RepositoryLabel](client, getRepositoryLabelsURI(project, repoSlug))
+	if err != nil {
+		return nil, err
+	}
+
+	labels := []string{}
+	for _, label := range repositoryLabels {
+		labels = append(labels, label.Name)
+	}
+
+	return labels, nil
+}
+
+func getRepositoryDefaultBranchURI(project string, repoSlug string) string {
+	return fmt.Sprintf("/rest/api/latest/projects/%s/repos/%s/default-branch",
+		url.PathEscape(project),
+		url.PathEscape(repoSlug),
+	)
+}
+
+func handleRepositoryDefaultBranchChanges(client *client.BitbucketClient, project string, repoSlug string, d *schema.ResourceData) error {
+	defaultBranch := d.Get("default_branch").(string)
+	if defaultBranch == "" || !d.HasChange("default_branch") {
+		return nil
+	}
+
+	bytedata, err := json.Marshal(&RepositoryDefaultBranch{
+		ID: "refs/heads/" + strings.TrimPrefix(defaultBranch, "refs/heads/"),
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Put(getRepositoryDefaultBranchURI(project, repoSlug), bytes.NewBuffer(bytedata))
+
+	return err
+}
+
+// readRepositoryDefaultBranch returns an empty string if the repository has no default branch yet.
+func readRepositoryDefaultBranch(client *client.BitbucketClient, project string, repoSlug string) (string, error) {
+	resp, err := client.Get(getRepositoryDefaultBranchURI(project, repoSlug))
+	if resp != nil && resp.StatusCode == 404 {
+		return "", nil
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode == 204 {
+		return "", nil
+	}
+
+	var defaultBranch RepositoryDefaultBranch
+
+	decoder := json.NewDecoder(resp.Body)
+	err = decoder.Decode(&defaultBranch)
+	if err != nil {
+		return "", err
+	}
+
+	return defaultBranch.DisplayID, nil
+}
+
+// suppressDiffAfterCreation ignores changes of attributes which are only used when the repository is created.
+func suppressDiffAfterCreation(_, _, _ string, d *schema.ResourceData) bool {
+	return d.Id() != ""
+}
+
+// initializeRepository commits the files of the initialize block to the default branch of the new, empty repository.
+func initializeRepository(client *client.BitbucketClient, project string, repoSlug string, d *schema.ResourceData) error {
+	initialize := d.Get("initialize").([]interface{})
+	if len(initialize) == 0 || initialize[0] == nil {
+		return nil
+	}
+
+	branch := d.Get("default_branch").(string)
+	if branch == "" {
+		var err error
+		branch, err = readRepositoryDefaultBranch(client, project, repoSlug)
+		if err != nil {
+			return err
+		}
+
+		if branch == "" {
+			return fmt.Errorf("the default branch of repository %s/%s is unknown, set default_branch to initialize it", project, repoSlug)
+		}
+	}
+
+	config := initialize[0].(map[string]interface{})
+	files := []struct {
+		path    string
+		content string
+	}{
+		{path: "README.md", content: config["readme"].(string)},
+		{path: ".gitignore", content: config["gitignore"].(string)},
+		{path: ".bitbucket/CODEOWNERS", content: config["codeowners"].(string)},
+	}
+
+	for _, file := range files {
+		if file.content == "" {
+			continue
+		}
+
+		// the first commit creates the branch, the following ones add new files to it
+		_, err := commitRepositoryFile(client, project, repoSlug, file.path, file.content, branch, config["commit_message"].(string), "")
+		if err != nil {
+			return fmt.Errorf("failed to commit %s to repository %s/%s: %v", file.path, project, repoSlug, err)
+		}
+	}
+
+	return nil
+}
+
 func resourceRepositoryRead(d *schema.ResourceData, m interface{}) error {
 	id := d.Id()
 	if id != "" {
@@ -300,6 +655,7 @@ func resourceRepositoryRead(d *schema.ResourceData, m interface{}) error {
 		_ = d.Set("description", repo.Description)
 		_ = d.Set("forkable", repo.Forkable)
 		_ = d.Set("public", repo.Public)
+		_ = d.Set("archived", repo.Archived != nil && *repo.Archived)
 
 		for _, clone_url := range repo.Links.Clone {
 			if clone_url.Name == "http" {
@@ -314,6 +670,20 @@ func resourceRepositoryRead(d *schema.ResourceData, m interface{}) error {
 			repoSlug,
 		))
 		_ = d.Set("enable_git_lfs", err == nil && gifLFS.StatusCode == 200)
+
+		labels, err := readRepositoryLabels(client, project, repoSlug)
+		if err != nil {
+			return err
+		}
+		_ = d.Set("labels", labels)
+
+		defaultBranch, err := readRepositoryDefaultBranch(client, project, repoSlug)
+		if err != nil {
+			return err
+		}
+		if defaultBranch != "" {
+			_ = d.Set("default_branch", defaultBranch)
+		}
 	}
 
 	return nil
@@ -351,14 +721,75 @@ func resourceRepositoryExists(d *schema.ResourceData, m interface{}) (bool, erro
 	}
 }
 
-func resourceRepositoryDelete(d *schema.ResourceData, m interface{}) error {
+func resourceRepositoryDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	repoSlug := determineSlug(d)
 	project := d.Get("project").(string)
 	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+
+	if d.Get("destroy_behavior").(string) == "archive" {
+		return diag.FromErr(archiveRepository(client, project, repoSlug))
+	}
+
 	_, err := client.Delete(fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s",
 		project,
 		repoSlug,
 	))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(waitForRepositoryDeletion(ctx, client, project, repoSlug, d.Timeout(schema.TimeoutDelete)))
+}
+
+// waitForRepositoryDeletion polls until the repository is gone. Bitbucket deletes repositories asynchronously,
+// until then the name can't be reused and the project can't be deleted.
+func waitForRepositoryDeletion(ctx context.Context, client *client.BitbucketClient, project string, repoSlug string, timeout time.Duration) error {
+	stateConf := &retry.StateChangeConf{
+		Pending: []string{"DELETION_PENDING"},
+		Target:  []string{"DELETED"},
+		Refresh: func() (interface{}, string, error) {
+			resp, err := client.Get(fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s",
+				project,
+				repoSlug,
+			))
+
+			if resp != nil && resp.StatusCode == 404 {
+				return project + "/" + repoSlug, "DELETED", nil
+			}
+
+			if err != nil {
+				return nil, "", err
+			}
+
+			return project + "/" + repoSlug, "DELETION_PENDING", nil
+		},
+		Timeout:    timeout,
+		Delay:      time.Second,
+		MinTimeout: time.Second,
+	}
+
+	_, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return fmt.Errorf("repository %s/%s wasn't deleted: %v", project, repoSlug, err)
+	}
+
+	return nil
+}
+
+// archiveRepository keeps the repository and its history, it is only made read-only.
+func archiveRepository(client *client.BitbucketClient, project string, repoSlug string) error {
+	// a partial update, a complete Repository would also reset forkable
+	bytedata, err := json.Marshal(map[string]bool{
+		"archived": true,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Put(fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s",
+		project,
+		repoSlug,
+	), bytes.NewBuffer(bytedata))
 
 	return err
 }
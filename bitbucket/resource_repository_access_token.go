@@ -15,14 +15,16 @@ import (
 )
 
 type repositoryAccessTokenModel struct {
-	Id          types.String `tfsdk:"id"`
-	Name        types.String `tfsdk:"name"`
-	Permissions types.Set    `tfsdk:"permissions"`
-	ExpireIn    types.Int64  `tfsdk:"expire_in"`
-	Project     types.String `tfsdk:"project"`
-	Repository  types.String `tfsdk:"repository"`
-	Token       types.String `tfsdk:"token"`
-	CreatedDate types.Int64  `tfsdk:"created_date"`
+	Id               types.String `tfsdk:"id"`
+	Name             types.String `tfsdk:"name"`
+	Permissions      types.Set    `tfsdk:"permissions"`
+	ExpireIn         types.Int64  `tfsdk:"expire_in"`
+	Project          types.String `tfsdk:"project"`
+	Repository       types.String `tfsdk:"repository"`
+	Token            types.String `tfsdk:"token"`
+	CreatedDate      types.Int64  `tfsdk:"created_date"`
+	RotateBeforeDays types.Int64  `tfsdk:"rotate_before_days"`
+	Keepers          types.Map    `tfsdk:"keepers"`
 }
 
 type repositoryAccessTokenResource struct {
@@ -37,6 +39,7 @@ func newRepositoryAccessTokenResource() resource.Resource {
 
 // Ensure the implementation satisfies the desired interfaces.
 var _ resource.ResourceWithConfigure = &repositoryAccessTokenResource{}
+var _ resource.ResourceWithModifyPlan = &repositoryAccessTokenResource{}
 
 // Metadata should return the full name of the resource.
 func (r *repositoryAccessTokenResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -149,6 +152,10 @@ func (r *repositoryAccessTokenResource) Delete(ctx context.Context, req resource
 	}
 }
 
+func (r *repositoryAccessTokenResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.resourceHelper.ModifyPlan(ctx, req, resp)
+}
+
 func (r *repositoryAccessTokenResource) Configure(ctx context.Context, configureRequest resource.ConfigureRequest, configureResponse *resource.ConfigureResponse) {
 	r.resourceHelper.Configure(ctx, configureRequest, configureResponse)
 }
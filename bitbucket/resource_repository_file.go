@@ -0,0 +1,228 @@
+package bitbucket
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	bitbucketTypes "github.com/xvlcwk-terraform/terraform-provider-bitbucketserver/bitbucket/util/types"
+	"log"
+	"strings"
+)
+
+func resourceRepositoryFile() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceRepositoryFileCreate,
+		Read:   resourceRepositoryFileRead,
+		Update: resourceRepositoryFileUpdate,
+		Delete: resourceRepositoryFileDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceRepositoryFileImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"repository": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"path": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Path of the file within the repository.",
+				StateFunc: func(val interface{}) string {
+					return strings.Trim(val.(string), "/")
+				},
+			},
+			"branch": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "Branch to commit the file to, the default branch if omitted.",
+				StateFunc: func(val interface{}) string {
+					return strings.TrimPrefix(val.(string), "refs/heads/")
+				},
+			},
+			"content": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"commit_message": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Message of the commits creating or updating the file.",
+			},
+			"overwrite_on_create": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Overwrite an already existing file instead of failing when the resource is created.",
+			},
+			"last_commit_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The commit written by the last apply.",
+			},
+			"remote_commit_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The last commit changing the file on the branch, updates are based on it. Differs from last_commit_id if the file was changed outside of Terraform.",
+			},
+		},
+	}
+}
+
+func resourceRepositoryFileCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+	project := d.Get("project").(string)
+	repository := d.Get("repository").(string)
+	path := strings.Trim(d.Get("path").(string), "/")
+
+	branch := strings.TrimPrefix(d.Get("branch").(string), "refs/heads/")
+	if branch == "" {
+		var err error
+		branch, err = readRepositoryDefaultBranch(client, project, repository)
+		if err != nil {
+			return err
+		}
+
+		if branch == "" {
+			return fmt.Errorf("repository %s/%s has no default branch, set branch explicitly", project, repository)
+		}
+	}
+
+	// files deleted in the history still have a latest commit, so the content decides whether the file exists
+	existing, err := readRawRepositoryFile(client, project, repository, path, "refs/heads/"+branch)
+	if err != nil {
+		return err
+	}
+
+	sourceCommitID := ""
+	if existing != nil {
+		if !d.Get("overwrite_on_create").(bool) {
+			return fmt.Errorf("file %s already exists on branch %s of repository %s/%s, import it or set overwrite_on_create = true", path, branch, project, repository)
+		}
+
+		latest, err := readLatestCommit(client, project, repository, "refs/heads/"+branch, path)
+		if err != nil {
+			return err
+		}
+
+		if latest != nil {
+			sourceCommitID = latest.ID
+		}
+	}
+
+	commit, err := commitRepositoryFile(client, project, repository, path, d.Get("content").(string), branch, repositoryFileCommitMessage(d, "Add", path), sourceCommitID)
+	if err != nil {
+		return err
+	}
+
+	_ = d.Set("branch", branch)
+	_ = d.Set("last_commit_id", commit.ID)
+	d.SetId(fmt.Sprintf("%s/%s/%s:%s", project, repository, branch, path))
+
+	return resourceRepositoryFileRead(d, m)
+}
+
+func resourceRepositoryFileUpdate(d *schema.ResourceData, m interface{}) error {
+	// a changed commit message alone isn't worth a commit
+	if !d.HasChange("content") {
+		return resourceRepositoryFileRead(d, m)
+	}
+
+	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+	project := d.Get("project").(string)
+	repository := d.Get("repository").(string)
+	path := strings.Trim(d.Get("path").(string), "/")
+	branch := d.Get("branch").(string)
+
+	// the update is based on the commit seen by the last refresh, so changes made outside of Terraform are overwritten,
+	// while a change pushed in between is rejected by Bitbucket with a conflict
+	remoteCommitID := d.Get("remote_commit_id").(string)
+
+	resp, err := commitRepositoryFile(client, project, repository, path, d.Get("content").(string), branch, repositoryFileCommitMessage(d, "Update", path), remoteCommitID)
+	if err != nil {
+		return fmt.Errorf("failed to update %s on branch %s of repository %s/%s, it might have been changed since commit %s, refresh and apply again: %v", path, branch, project, repository, remoteCommitID, err)
+	}
+
+	_ = d.Set("last_commit_id", resp.ID)
+
+	return resourceRepositoryFileRead(d, m)
+}
+
+func resourceRepositoryFileRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+	project := d.Get("project").(string)
+	repository := d.Get("repository").(string)
+	path := strings.Trim(d.Get("path").(string), "/")
+	at := "refs/heads/" + strings.TrimPrefix(d.Get("branch").(string), "refs/heads/")
+
+	content, err := readRawRepositoryFile(client, project, repository, path, at)
+	if err != nil {
+		return err
+	}
+
+	if content == nil {
+		log.Printf("[WARN] File %s not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	commit, err := readLatestCommit(client, project, repository, at, path)
+	if err != nil {
+		return err
+	}
+
+	_ = d.Set("content", string(content))
+	if commit != nil {
+		_ = d.Set("remote_commit_id", commit.ID)
+
+		// only set by an apply, except after an import
+		if d.Get("last_commit_id").(string) == "" {
+			_ = d.Set("last_commit_id", commit.ID)
+		}
+	}
+
+	return nil
+}
+
+func resourceRepositoryFileDelete(d *schema.ResourceData, _ interface{}) error {
+	// the REST API of Bitbucket can only create and edit files, not delete them
+	log.Printf("[WARN] File %s is only removed from the state, it is kept in the repository", d.Id())
+	return nil
+}
+
+// resourceRepositoryFileImport expects an ID in the format `project/repository/branch:path`, branches can't contain a colon.
+func resourceRepositoryFileImport(d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), "/", 3)
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("incorrect ID format, should match `project/repository/branch:path`")
+	}
+
+	refAndPath := strings.SplitN(parts[2], ":", 2)
+	if len(refAndPath) != 2 || refAndPath[0] == "" || refAndPath[1] == "" {
+		return nil, fmt.Errorf("incorrect ID format, should match `project/repository/branch:path`")
+	}
+
+	_ = d.Set("project", parts[0])
+	_ = d.Set("repository", parts[1])
+	_ = d.Set("branch", strings.TrimPrefix(refAndPath[0], "refs/heads/"))
+	_ = d.Set("path", strings.Trim(refAndPath[1], "/"))
+	_ = d.Set("overwrite_on_create", false)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func repositoryFileCommitMessage(d *schema.ResourceData, action string, path string) string {
+	if message := d.Get("commit_message").(string); message != "" {
+		return message
+	}
+
+	return fmt.Sprintf("%s %s", action, path)
+}
@@ -0,0 +1,96 @@
+package bitbucket
+
+import (
+	"fmt"
+	bitbucketTypes "github.com/xvlcwk-terraform/terraform-provider-bitbucketserver/bitbucket/util/types"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccBitbucketResourceRepositoryFile(t *testing.T) {
+	projectKey := fmt.Sprintf("TEST%v", rand.New(rand.NewSource(time.Now().UnixNano())).Int())
+
+	config := fmt.Sprintf(`
+		resource "bitbucketserver_project" "test" {
+			key  = "%v"
+			name = "test-project-%v"
+		}
+
+		resource "bitbucketserver_repository" "test" {
+			project        = bitbucketserver_project.test.key
+			name           = "repo"
+			default_branch = "main"
+
+			initialize {
+				readme = "# repo"
+			}
+		}
+
+		resource "bitbucketserver_repository_file" "test" {
+			project        = bitbucketserver_project.test.key
+			repository     = bitbucketserver_repository.test.slug
+			path           = ".bitbucket/pull-request-template.md"
+			content        = "## Summary"
+			commit_message = "Manage the pull request template"
+		}
+	`, projectKey, projectKey)
+
+	configModified := strings.ReplaceAll(config, "## Summary", "## What changed")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucketserver_repository_file.test", "id", projectKey+"/repo/main:.bitbucket/pull-request-template.md"),
+					resource.TestCheckResourceAttr("bitbucketserver_repository_file.test", "branch", "main"),
+					resource.TestCheckResourceAttr("bitbucketserver_repository_file.test", "content", "## Summary"),
+					resource.TestCheckResourceAttrSet("bitbucketserver_repository_file.test", "last_commit_id"),
+				),
+			},
+			{
+				Config: configModified,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucketserver_repository_file.test", "content", "## What changed"),
+				),
+			},
+			{
+				// a change made outside of Terraform is overwritten
+				PreConfig: func() {
+					testAccCommitRepositoryFileOutsideOfTerraform(t, projectKey, "repo", "main", ".bitbucket/pull-request-template.md", "## Changed elsewhere")
+				},
+				Config: configModified,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucketserver_repository_file.test", "content", "## What changed"),
+					resource.TestCheckResourceAttrPair("bitbucketserver_repository_file.test", "last_commit_id", "bitbucketserver_repository_file.test", "remote_commit_id"),
+				),
+			},
+			{
+				ResourceName:            "bitbucketserver_repository_file.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"commit_message"},
+			},
+		},
+	})
+}
+
+func testAccCommitRepositoryFileOutsideOfTerraform(t *testing.T, project string, repository string, branch string, path string, content string) {
+	client := testAccProvider.Meta().(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+
+	remote, err := readLatestCommit(client, project, repository, "refs/heads/"+branch, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = commitRepositoryFile(client, project, repository, path, content, branch, "Change outside of Terraform", remote.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
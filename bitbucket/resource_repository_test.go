@@ -1,9 +1,11 @@
 package bitbucket
 
 import (
+	"encoding/json"
 	"fmt"
 	bitbucketTypes "github.com/xvlcwk-terraform/terraform-provider-bitbucketserver/bitbucket/util/types"
 	"math/rand"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
@@ -196,6 +198,352 @@ func TestAccBitbucketRepository_gitlfs(t *testing.T) {
 	})
 }
 
+func TestAccBitbucketRepository_labels(t *testing.T) {
+	var repo Repository
+
+	config := fmt.Sprintf(`
+		resource "bitbucketserver_project" "test" {
+			key = "TEST%v"
+			name = "test-repo-for-repository-test"
+		}
+
+		resource "bitbucketserver_repository" "test_repo" {
+			project = bitbucketserver_project.test.key
+			name = "test-repo-for-repository-test"
+			labels = ["tier-1", "team-platform"]
+		}
+	`, rand.New(rand.NewSource(time.Now().UnixNano())).Int())
+
+	configModified := strings.ReplaceAll(config, `"tier-1", "team-platform"`, `"tier-2", "team-platform"`)
+	configWithoutLabels := strings.ReplaceAll(config, `labels = ["tier-1", "team-platform"]`, "")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBitbucketRepositoryDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckBitbucketRepositoryExists("bitbucketserver_repository.test_repo", &repo),
+					resource.TestCheckResourceAttr("bitbucketserver_repository.test_repo", "labels.#", "2"),
+					resource.TestCheckTypeSetElemAttr("bitbucketserver_repository.test_repo", "labels.*", "tier-1"),
+					resource.TestCheckTypeSetElemAttr("bitbucketserver_repository.test_repo", "labels.*", "team-platform"),
+				),
+			},
+			{
+				Config: configModified,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucketserver_repository.test_repo", "labels.#", "2"),
+					resource.TestCheckTypeSetElemAttr("bitbucketserver_repository.test_repo", "labels.*", "tier-2"),
+					resource.TestCheckTypeSetElemAttr("bitbucketserver_repository.test_repo", "labels.*", "team-platform"),
+				),
+			},
+			{
+				// removing the attribute removes all labels
+				Config: configWithoutLabels,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucketserver_repository.test_repo", "labels.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccBitbucketRepository_archived(t *testing.T) {
+	var repo Repository
+
+	config := fmt.Sprintf(`
+		resource "bitbucketserver_project" "test" {
+			key = "TEST%v"
+			name = "test-repo-for-repository-test"
+		}
+
+		resource "bitbucketserver_repository" "test_repo" {
+			project = bitbucketserver_project.test.key
+			name = "test-repo-for-repository-test"
+			archived = false
+		}
+	`, rand.New(rand.NewSource(time.Now().UnixNano())).Int())
+
+	configArchived := strings.ReplaceAll(config, "archived = false", "archived = true")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBitbucketRepositoryDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckBitbucketRepositoryExists("bitbucketserver_repository.test_repo", &repo),
+					resource.TestCheckResourceAttr("bitbucketserver_repository.test_repo", "archived", "false"),
+					resource.TestCheckResourceAttr("bitbucketserver_repository.test_repo", "destroy_behavior", "delete"),
+				),
+			},
+			{
+				Config: configArchived,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucketserver_repository.test_repo", "archived", "true"),
+				),
+			},
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucketserver_repository.test_repo", "archived", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccBitbucketRepository_destroyBehaviorArchive(t *testing.T) {
+	var repo Repository
+
+	projectKey := fmt.Sprintf("TEST%v", rand.New(rand.NewSource(time.Now().UnixNano())).Int())
+
+	// the archived repository is left behind, force_destroy removes it with the project
+	projectConfig := fmt.Sprintf(`
+		resource "bitbucketserver_project" "test" {
+			key = "%v"
+			name = "test-repo-for-repository-test"
+			force_destroy = true
+		}
+	`, projectKey)
+
+	config := projectConfig + `
+		resource "bitbucketserver_repository" "test_repo" {
+			project = bitbucketserver_project.test.key
+			name = "test-repo-for-repository-test"
+			destroy_behavior = "archive"
+		}
+	`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckBitbucketRepositoryExists("bitbucketserver_repository.test_repo", &repo),
+					resource.TestCheckResourceAttr("bitbucketserver_repository.test_repo", "destroy_behavior", "archive"),
+					resource.TestCheckResourceAttr("bitbucketserver_repository.test_repo", "archived", "false"),
+				),
+			},
+			{
+				Config: projectConfig,
+				Check: func(s *terraform.State) error {
+					client := testAccProvider.Meta().(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+
+					resp, err := client.Get(fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s", projectKey, "test-repo-for-repository-test"))
+					if err != nil {
+						return fmt.Errorf("repository was deleted instead of archived: %v", err)
+					}
+
+					var archived Repository
+
+					decoder := json.NewDecoder(resp.Body)
+					err = decoder.Decode(&archived)
+					if err != nil {
+						return err
+					}
+
+					if archived.Archived == nil || !*archived.Archived {
+						return fmt.Errorf("repository %s/%s isn't archived", projectKey, archived.Slug)
+					}
+
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccBitbucketRepository_renameAndMove(t *testing.T) {
+	projectKey := fmt.Sprintf("TEST%v", rand.New(rand.NewSource(time.Now().UnixNano())).Int())
+
+	configString := `
+		resource "bitbucketserver_project" "test" {
+			key = "%v"
+			name = "test-project-%v"
+		}
+
+		resource "bitbucketserver_project" "other" {
+			key = "%vO"
+			name = "test-project-%v-other"
+		}
+
+		resource "bitbucketserver_repository" "test_repo" {
+			project = bitbucketserver_project.%s.key
+			name = "%s"
+		}
+	`
+
+	config := fmt.Sprintf(configString, projectKey, projectKey, projectKey, projectKey, "test", "original-name")
+	configRenamed := fmt.Sprintf(configString, projectKey, projectKey, projectKey, projectKey, "test", "renamed")
+	configMoved := fmt.Sprintf(configString, projectKey, projectKey, projectKey, projectKey, "other", "renamed")
+	configRenamedWithSlug := strings.ReplaceAll(
+		fmt.Sprintf(configString, projectKey, projectKey, projectKey, projectKey, "other", "renamed-again"),
+		`name = "renamed-again"`,
+		`name = "renamed-again"
+			slug = "renamed"`,
+	)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBitbucketRepositoryDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucketserver_repository.test_repo", "slug", "original-name"),
+				),
+			},
+			{
+				Config: configRenamed,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucketserver_repository.test_repo", "id", projectKey+"/renamed"),
+					resource.TestCheckResourceAttr("bitbucketserver_repository.test_repo", "slug", "renamed"),
+					resource.TestMatchResourceAttr("bitbucketserver_repository.test_repo", "clone_https", regexp.MustCompile("/renamed.git$")),
+				),
+			},
+			{
+				Config: configMoved,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucketserver_repository.test_repo", "id", projectKey+"O/renamed"),
+					resource.TestCheckResourceAttr("bitbucketserver_repository.test_repo", "project", projectKey+"O"),
+				),
+			},
+			{
+				Config:      configRenamedWithSlug,
+				ExpectError: regexp.MustCompile("can't be renamed while slug is configured"),
+			},
+		},
+	})
+}
+
+func TestAccBitbucketRepository_deleteAndRecreate(t *testing.T) {
+	var repo Repository
+
+	projectConfig := fmt.Sprintf(`
+		resource "bitbucketserver_project" "test" {
+			key = "TEST%v"
+			name = "test-repo-for-repository-test"
+
+			timeouts {
+				delete = "10m"
+			}
+		}
+	`, rand.New(rand.NewSource(time.Now().UnixNano())).Int())
+
+	config := projectConfig + `
+		resource "bitbucketserver_repository" "test_repo" {
+			project = bitbucketserver_project.test.key
+			name = "test-repo-for-repository-test"
+
+			timeouts {
+				delete = "10m"
+			}
+		}
+	`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBitbucketRepositoryDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckBitbucketRepositoryExists("bitbucketserver_repository.test_repo", &repo),
+				),
+			},
+			{
+				Config: projectConfig,
+			},
+			{
+				// the name is only free again once the previous repository is really gone
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckBitbucketRepositoryExists("bitbucketserver_repository.test_repo", &repo),
+					resource.TestCheckResourceAttr("bitbucketserver_repository.test_repo", "slug", "test-repo-for-repository-test"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccBitbucketRepository_defaultBranchAndInitialize(t *testing.T) {
+	var repo Repository
+
+	config := fmt.Sprintf(`
+		resource "bitbucketserver_project" "test" {
+			key = "TEST%v"
+			name = "test-repo-for-repository-test"
+		}
+
+		resource "bitbucketserver_repository" "test_repo" {
+			project = bitbucketserver_project.test.key
+			name = "test-repo-for-repository-test"
+			default_branch = "main"
+
+			initialize {
+				readme = "# test-repo-for-repository-test"
+				gitignore = "*.tfstate"
+			}
+		}
+
+		data "bitbucketserver_repository_file" "readme" {
+			project = bitbucketserver_repository.test_repo.project
+			repository = bitbucketserver_repository.test_repo.slug
+			path = "README.md"
+			ref = "main"
+		}
+
+		data "bitbucketserver_repository_file" "gitignore" {
+			project = bitbucketserver_repository.test_repo.project
+			repository = bitbucketserver_repository.test_repo.slug
+			path = ".gitignore"
+			ref = "main"
+		}
+	`, rand.New(rand.NewSource(time.Now().UnixNano())).Int())
+
+	configModified := strings.ReplaceAll(config, `default_branch = "main"`, `default_branch = "master"`)
+	configReadmeChanged := strings.ReplaceAll(config, `readme = "# test-repo-for-repository-test"`, `readme = "# changed"`)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBitbucketRepositoryDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckBitbucketRepositoryExists("bitbucketserver_repository.test_repo", &repo),
+					resource.TestCheckResourceAttr("bitbucketserver_repository.test_repo", "default_branch", "main"),
+					resource.TestCheckResourceAttr("bitbucketserver_repository.test_repo", "initialize.0.commit_message", "Initial commit"),
+					resource.TestCheckResourceAttr("data.bitbucketserver_repository_file.readme", "content", "# test-repo-for-repository-test"),
+					resource.TestCheckResourceAttr("data.bitbucketserver_repository_file.readme", "last_commit_message", "Initial commit"),
+					resource.TestCheckResourceAttr("data.bitbucketserver_repository_file.gitignore", "content", "*.tfstate"),
+				),
+			},
+			{
+				// initialize is only applied on creation, later changes don't show in the plan
+				Config:   configReadmeChanged,
+				PlanOnly: true,
+			},
+			{
+				Config: configModified,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucketserver_repository.test_repo", "default_branch", "master"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckBitbucketRepositoryDestroy(s *terraform.State) error {
 	client := testAccProvider.Meta().(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
 	rs, ok := s.RootModule().Resources["bitbucketserver_repository.test_repo"]
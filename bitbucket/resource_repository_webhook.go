@@ -5,40 +5,71 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	bitbucketTypes "github.com/xvlcwk-terraform/terraform-provider-bitbucketserver/bitbucket/util/types"
 	"io/ioutil"
+	"log"
+	"net/url"
+	"sort"
 	"strings"
 )
 
 type WebhookConfiguration struct {
-	Secret string `json:"secret,omitempty"`
+	Secret        string `json:"secret,omitempty"`
+	HttpMethod    string `json:"httpMethod,omitempty"`
+	CustomHeaders string `json:"customHeaders,omitempty"`
+}
+
+type WebhookCredentials struct {
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
 }
 
 type Webhook struct {
-	ID            int                  `json:"id,omitempty"`
-	Name          string               `json:"name,omitempty"`
-	CreatedDate   jsonTime             `json:"createdDate,omitempty"`
-	UpdatedDate   jsonTime             `json:"updatedDate,omitempty"`
-	URL           string               `json:"url,omitempty"`
-	Active        bool                 `json:"active"`
-	Events        []interface{}        `json:"events"`
-	Configuration WebhookConfiguration `json:"configuration"`
+	ID                      int                  `json:"id,omitempty"`
+	Name                    string               `json:"name,omitempty"`
+	CreatedDate             jsonTime             `json:"createdDate,omitempty"`
+	UpdatedDate             jsonTime             `json:"updatedDate,omitempty"`
+	URL                     string               `json:"url,omitempty"`
+	Active                  bool                 `json:"active"`
+	Events                  []WebhookEvent       `json:"events"`
+	Configuration           WebhookConfiguration `json:"configuration"`
+	SslVerificationRequired bool                 `json:"sslVerificationRequired"`
+	Credentials             *WebhookCredentials  `json:"credentials,omitempty"`
+}
+
+type WebhookInvocation struct {
+	Start  jsonTime `json:"start,omitempty"`
+	Finish jsonTime `json:"finish,omitempty"`
+}
+
+type WebhookStatisticsSummary struct {
+	LastSuccess *WebhookInvocation `json:"lastSuccess,omitempty"`
+	LastFailure *WebhookInvocation `json:"lastFailure,omitempty"`
+	LastError   *WebhookInvocation `json:"lastError,omitempty"`
 }
 
-type WebhookListResponse struct {
-	Size       int       `json:"size,omitempty"`
-	Limit      int       `json:"limit,omitempty"`
-	Start      int       `json:"start,omitempty"`
-	IsLastPage bool      `json:"isLastPage,omitempty"`
-	Values     []Webhook `json:"values"`
+type WebhookTestResponse struct {
+	Request *struct {
+		URL    string `json:"url,omitempty"`
+		Method string `json:"method,omitempty"`
+	} `json:"request,omitempty"`
+	Response *struct {
+		StatusCode int    `json:"statusCode,omitempty"`
+		Body       string `json:"body,omitempty"`
+	} `json:"response,omitempty"`
+	Error *struct {
+		Message string `json:"message,omitempty"`
+	} `json:"error,omitempty"`
 }
 
 func resourceRepositoryWebhook() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceRepositoryWebhookCreate,
-		Update: resourceRepositoryWebhookUpdate,
-		Read:   resourceRepositoryWebhookRead,
-		Delete: resourceRepositoryWebhookDelete,
+		Create:        resourceRepositoryWebhookCreate,
+		Update:        resourceRepositoryWebhookUpdate,
+		Read:          resourceRepositoryWebhookRead,
+		Delete:        resourceRepositoryWebhookDelete,
+		CustomizeDiff: validateWebhookEventsVersion,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -65,10 +96,15 @@ func resourceRepositoryWebhook() *schema.Resource {
 				ForceNew: false,
 			},
 			"events": {
-				Type:     schema.TypeList,
+				Type:     schema.TypeSet,
 				Required: true,
 				ForceNew: false,
-				Elem:     &schema.Schema{Type: schema.TypeString},
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(validWebhookEvents(), false),
+				},
+				Set:         schema.HashString,
+				Description: "Events triggering the webhook. Events unknown to the provider or unsupported by the Bitbucket version are rejected during plan.",
 			},
 			"secret": {
 				Type:      schema.TypeString,
@@ -81,54 +117,134 @@ func resourceRepositoryWebhook() *schema.Resource {
 				Optional: true,
 				Default:  true,
 			},
+			"ssl_verification_required": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"http_method": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "POST",
+				ValidateFunc: validation.StringInSlice([]string{"POST", "PUT"}, false),
+			},
+			"custom_headers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Custom HTTP headers sent with every request of the webhook.",
+			},
+			"username": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"password"},
+				Description:  "Username for basic authentication against the webhook url.",
+			},
+			"password": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				RequiredWith: []string{"username"},
+				Description:  "Password for basic authentication against the webhook url. It can't be read back from Bitbucket.",
+			},
+			"verify_on_create": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Send a test request to the webhook url before creating the webhook and fail if it is unreachable.",
+			},
 			"webhook_id": {
 				Type:     schema.TypeInt,
 				Computed: true,
 			},
+			"last_success": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"last_failure": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
 
 func resourceRepositoryWebhookUpdate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
-
-	project := d.Get("project").(string)
-	repository := d.Get("repository").(string)
-	id := d.Get("webhook_id").(int)
-	webhook := newWebhookFromResource(d)
-
-	request, err := json.Marshal(webhook)
-
+	err := updateWebhook(d, m, getWebhooksURI(d.Get("project").(string), d.Get("repository").(string)))
 	if err != nil {
 		return err
 	}
 
-	_, err = client.Put(fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/webhooks/%d",
-		project,
-		repository,
-		id,
-	), bytes.NewBuffer(request))
+	return resourceRepositoryWebhookRead(d, m)
+}
 
+func resourceRepositoryWebhookCreate(d *schema.ResourceData, m interface{}) error {
+	err := createWebhook(d, m, getWebhooksURI(d.Get("project").(string), d.Get("repository").(string)))
 	if err != nil {
 		return err
 	}
 
+	d.SetId(fmt.Sprintf("%s/%s/%s", d.Get("project").(string), d.Get("repository").(string), d.Get("name").(string)))
 	return resourceRepositoryWebhookRead(d, m)
 }
 
-func resourceRepositoryWebhookCreate(d *schema.ResourceData, m interface{}) error {
+func resourceRepositoryWebhookRead(d *schema.ResourceData, m interface{}) error {
+	id := d.Id()
+	if id != "" {
+		parts := strings.Split(id, "/")
+		if len(parts) == 3 {
+			_ = d.Set("project", parts[0])
+			_ = d.Set("repository", parts[1])
+			_ = d.Set("name", parts[2])
+		} else {
+			return fmt.Errorf("incorrect ID format, should match `project/repository/name`")
+		}
+	}
+
+	err := readWebhook(d, m, getWebhooksURI(d.Get("project").(string), d.Get("repository").(string)))
+	if err != nil {
+		return fmt.Errorf("%v, ID should match `project/repository/name`", err)
+	}
+
+	return nil
+}
+
+func resourceRepositoryWebhookDelete(d *schema.ResourceData, m interface{}) error {
+	return deleteWebhook(d, m, getWebhooksURI(d.Get("project").(string), d.Get("repository").(string)))
+}
+
+// getWebhooksURI returns the webhooks endpoint of the repository, or of the project if repository is empty.
+func getWebhooksURI(project string, repository string) string {
+	if repository == "" {
+		return fmt.Sprintf("/rest/api/1.0/projects/%s/webhooks",
+			url.PathEscape(project),
+		)
+	}
+
+	return fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/webhooks",
+		url.PathEscape(project),
+		url.PathEscape(repository),
+	)
+}
+
+func createWebhook(d *schema.ResourceData, m interface{}, webhooksURI string) error {
 	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
 
-	project := d.Get("project").(string)
-	repository := d.Get("repository").(string)
 	webhook := newWebhookFromResource(d)
 
+	if d.Get("verify_on_create").(bool) {
+		err := testWebhook(m, webhooksURI, webhook)
+		if err != nil {
+			return err
+		}
+	}
+
 	request, err := json.Marshal(webhook)
+	if err != nil {
+		return err
+	}
 
-	res, err := client.Post(fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/webhooks",
-		project,
-		repository,
-	), bytes.NewBuffer(request))
+	res, err := client.Post(webhooksURI, bytes.NewBuffer(request))
 
 	if err != nil {
 		return err
@@ -149,46 +265,125 @@ func resourceRepositoryWebhookCreate(d *schema.ResourceData, m interface{}) erro
 	}
 
 	_ = d.Set("webhook_id", webhookResponse.ID)
-
-	d.SetId(fmt.Sprintf("%s/%s/%s", d.Get("project").(string), d.Get("repository").(string), d.Get("name").(string)))
-	return resourceRepositoryWebhookRead(d, m)
+	return nil
 }
 
-func resourceRepositoryWebhookRead(d *schema.ResourceData, m interface{}) error {
-	id := d.Id()
-	if id != "" {
-		parts := strings.Split(id, "/")
-		if len(parts) == 3 {
-			_ = d.Set("project", parts[0])
-			_ = d.Set("repository", parts[1])
-			_ = d.Set("name", parts[2])
-		} else {
-			return fmt.Errorf("incorrect ID format, should match `project/repository/name`")
-		}
+func updateWebhook(d *schema.ResourceData, m interface{}, webhooksURI string) error {
+	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+
+	webhook := newWebhookFromResource(d)
+
+	request, err := json.Marshal(webhook)
+
+	if err != nil {
+		return err
 	}
 
-	webhookId := d.Get("webhook_id").(int)
+	_, err = client.Put(fmt.Sprintf("%s/%d",
+		webhooksURI,
+		d.Get("webhook_id").(int),
+	), bytes.NewBuffer(request))
 
+	return err
+}
+
+func readWebhook(d *schema.ResourceData, m interface{}, webhooksURI string) error {
 	var err error
 
-	if webhookId != 0 {
-		err = getRepositoryWebhookFromId(d, m)
+	if d.Get("webhook_id").(int) != 0 {
+		err = getWebhookFromId(d, m, webhooksURI)
 	} else {
-		err = getRepositoryWebhookFromList(d, m)
+		err = getWebhookFromList(d, m, webhooksURI)
+	}
+
+	if err != nil || d.Id() == "" {
+		return err
+	}
+
+	readWebhookStatistics(d, m, webhooksURI)
+	return nil
+}
+
+// readWebhookStatistics stores the last delivery results. Statistics are informational, so failures are only logged.
+func readWebhookStatistics(d *schema.ResourceData, m interface{}, webhooksURI string) {
+	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+
+	resp, err := client.Get(fmt.Sprintf("%s/%d/statistics/summary",
+		webhooksURI,
+		d.Get("webhook_id").(int),
+	))
+
+	if err != nil {
+		log.Printf("[WARN] Failed to read statistics of webhook %d: %v", d.Get("webhook_id").(int), err)
+		return
+	}
+
+	var summary WebhookStatisticsSummary
+
+	decoder := json.NewDecoder(resp.Body)
+	err = decoder.Decode(&summary)
+	if err != nil {
+		log.Printf("[WARN] Failed to decode statistics of webhook %d: %v", d.Get("webhook_id").(int), err)
+		return
+	}
+
+	_ = d.Set("last_success", "")
+	if summary.LastSuccess != nil {
+		_ = d.Set("last_success", summary.LastSuccess.Finish.String())
+	}
+
+	_ = d.Set("last_failure", "")
+	if summary.LastFailure != nil {
+		_ = d.Set("last_failure", summary.LastFailure.Finish.String())
+	}
+}
+
+// testWebhook sends a test request to the url of the webhook and fails if the receiver is unreachable or responds with an error.
+func testWebhook(m interface{}, webhooksURI string, webhook *Webhook) error {
+	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+
+	request, err := json.Marshal(webhook)
+	if err != nil {
+		return err
 	}
 
+	resp, err := client.Post(fmt.Sprintf("%s/test?url=%s&sslVerificationRequired=%t",
+		webhooksURI,
+		url.QueryEscape(webhook.URL),
+		webhook.SslVerificationRequired,
+	), bytes.NewBuffer(request))
+
 	if err != nil {
 		return err
 	}
 
+	var testResponse WebhookTestResponse
+
+	decoder := json.NewDecoder(resp.Body)
+	err = decoder.Decode(&testResponse)
+	if err != nil {
+		return err
+	}
+
+	if testResponse.Error != nil {
+		return fmt.Errorf("test delivery to webhook url %s failed: %s", webhook.URL, testResponse.Error.Message)
+	}
+
+	if testResponse.Response == nil {
+		return fmt.Errorf("test delivery to webhook url %s failed: no response", webhook.URL)
+	}
+
+	if testResponse.Response.StatusCode < 200 || testResponse.Response.StatusCode >= 400 {
+		return fmt.Errorf("test delivery to webhook url %s failed with status %d: %s", webhook.URL, testResponse.Response.StatusCode, testResponse.Response.Body)
+	}
+
 	return nil
 }
 
-func resourceRepositoryWebhookDelete(d *schema.ResourceData, m interface{}) error {
+func deleteWebhook(d *schema.ResourceData, m interface{}, webhooksURI string) error {
 	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
-	_, err := client.Delete(fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/webhooks/%d",
-		d.Get("project").(string),
-		d.Get("repository").(string),
+	_, err := client.Delete(fmt.Sprintf("%s/%d",
+		webhooksURI,
 		d.Get("webhook_id").(int)))
 
 	return err
@@ -196,33 +391,92 @@ func resourceRepositoryWebhookDelete(d *schema.ResourceData, m interface{}) erro
 
 func newWebhookFromResource(d *schema.ResourceData) (Hook *Webhook) {
 	configuration := &WebhookConfiguration{
-		Secret: d.Get("secret").(string),
+		Secret:        d.Get("secret").(string),
+		HttpMethod:    d.Get("http_method").(string),
+		CustomHeaders: expandWebhookHeaders(d.Get("custom_headers").(map[string]interface{})),
 	}
 
 	webhook := &Webhook{
-		Name:          d.Get("name").(string),
-		URL:           d.Get("webhook_url").(string),
-		Active:        d.Get("active").(bool),
-		Events:        d.Get("events").([]interface{}),
-		Configuration: *configuration,
+		Name:                    d.Get("name").(string),
+		URL:                     d.Get("webhook_url").(string),
+		Active:                  d.Get("active").(bool),
+		Events:                  expandWebhookEvents(d.Get("events").(*schema.Set)),
+		Configuration:           *configuration,
+		SslVerificationRequired: d.Get("ssl_verification_required").(bool),
+	}
+
+	if username := d.Get("username").(string); username != "" {
+		webhook.Credentials = &WebhookCredentials{
+			Username: username,
+			Password: d.Get("password").(string),
+		}
 	}
 
 	return webhook
 }
 
-func getRepositoryWebhookFromId(d *schema.ResourceData, m interface{}) error {
-	project := d.Get("project").(string)
-	repository := d.Get("repository").(string)
+func setWebhookFromResponse(d *schema.ResourceData, webhook Webhook) {
+	_ = d.Set("webhook_id", webhook.ID)
+	_ = d.Set("webhook_url", webhook.URL)
+	_ = d.Set("active", webhook.Active)
+	_ = d.Set("events", collapseWebhookEvents(webhook.Events))
+	_ = d.Set("secret", webhook.Configuration.Secret)
+	_ = d.Set("ssl_verification_required", webhook.SslVerificationRequired)
+	_ = d.Set("custom_headers", collapseWebhookHeaders(webhook.Configuration.CustomHeaders))
+
+	httpMethod := webhook.Configuration.HttpMethod
+	if httpMethod == "" {
+		httpMethod = "POST"
+	}
+	_ = d.Set("http_method", httpMethod)
+
+	// the password is never returned, so it is kept as configured
+	if webhook.Credentials != nil {
+		_ = d.Set("username", webhook.Credentials.Username)
+	} else {
+		_ = d.Set("username", "")
+	}
+}
+
+// expandWebhookHeaders converts the headers into the "Name: value" lines Bitbucket stores in the configuration.
+func expandWebhookHeaders(headers map[string]interface{}) string {
+	lines := make([]string, 0, len(headers))
+	for name, value := range headers {
+		lines = append(lines, fmt.Sprintf("%s: %s", name, value.(string)))
+	}
+	sort.Strings(lines)
+
+	return strings.Join(lines, "\n")
+}
+
+func collapseWebhookHeaders(customHeaders string) map[string]interface{} {
+	headers := make(map[string]interface{})
+	for _, line := range strings.Split(customHeaders, "\n") {
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) == 2 {
+			headers[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+		}
+	}
+
+	return headers
+}
+
+func getWebhookFromId(d *schema.ResourceData, m interface{}, webhooksURI string) error {
 	id := d.Get("webhook_id").(int)
 
 	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
 
-	resp, err := client.Get(fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/webhooks/%d",
-		project,
-		repository,
+	resp, err := client.Get(fmt.Sprintf("%s/%d",
+		webhooksURI,
 		id,
 	))
 
+	if resp != nil && resp.StatusCode == 404 {
+		log.Printf("[WARN] Webhook %d not found, removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
 	if err != nil {
 		return err
 	}
@@ -237,51 +491,31 @@ func getRepositoryWebhookFromId(d *schema.ResourceData, m interface{}) error {
 		return err
 	}
 
-	_ = d.Set("webhook_id", webhook.ID)
-	_ = d.Set("webhook_url", webhook.URL)
-	_ = d.Set("active", webhook.Active)
-	_ = d.Set("events", webhook.Events)
-	_ = d.Set("secret", webhook.Configuration.Secret)
+	setWebhookFromResponse(d, webhook)
 
 	return nil
 }
 
-func getRepositoryWebhookFromList(d *schema.ResourceData, m interface{}) error {
-	project := d.Get("project").(string)
-	repository := d.Get("repository").(string)
+func getWebhookFromList(d *schema.ResourceData, m interface{}, webhooksURI string) error {
 	name := d.Get("name").(string)
 
-	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
-
-	resp, err := client.Get(fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/webhooks",
-		project,
-		repository,
-	))
-
+	webhooks, err := readWebhooks(m, webhooksURI)
 	if err != nil {
 		return err
 	}
 
-	var webhookListResponse WebhookListResponse
-
-	decoder := json.NewDecoder(resp.Body)
-
-	err = decoder.Decode(&webhookListResponse)
-
-	if err != nil {
-		return err
-	}
-
-	for _, webhook := range webhookListResponse.Values {
+	for _, webhook := range webhooks {
 		if webhook.Name == name {
-			_ = d.Set("webhook_id", webhook.ID)
-			_ = d.Set("webhook_url", webhook.URL)
-			_ = d.Set("active", webhook.Active)
-			_ = d.Set("events", webhook.Events)
-			_ = d.Set("secret", webhook.Configuration.Secret)
+			setWebhookFromResponse(d, webhook)
 			return nil
 		}
 	}
 
-	return fmt.Errorf("incorrect ID format, should match `project/repository/name`")
+	return fmt.Errorf("webhook %s not found", name)
+}
+
+func readWebhooks(m interface{}, webhooksURI string) ([]Webhook, error) {
+	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+
+	return readAllPages[Webhook](client, webhooksURI)
 }
@@ -3,6 +3,7 @@ package bitbucket
 import (
 	"fmt"
 	"math/rand"
+	"regexp"
 	"testing"
 	"time"
 
@@ -113,3 +114,78 @@ func TestAccBitbucketResourceRepositoryWebhook_complete(t *testing.T) {
 		},
 	})
 }
+
+func TestAccBitbucketResourceRepositoryWebhook_advanced(t *testing.T) {
+	projectKey := fmt.Sprintf("TEST%v", rand.New(rand.NewSource(time.Now().UnixNano())).Int())
+	config := fmt.Sprintf(`
+		%s
+
+		resource "bitbucketserver_repository_webhook" "test" {
+			project                   = bitbucketserver_project.test.key
+			repository                = bitbucketserver_repository.test.slug
+			name                      = "advanced"
+			webhook_url               = "https://www.google.com/"
+			events                    = ["repo:refs_changed"]
+			ssl_verification_required = false
+			http_method               = "PUT"
+			username                  = "admin"
+			password                  = "secret"
+			custom_headers = {
+				"X-Team" = "platform"
+			}
+		}
+	`, baseConfigForRepositoryBasedTests(projectKey))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucketserver_repository_webhook.test", "ssl_verification_required", "false"),
+					resource.TestCheckResourceAttr("bitbucketserver_repository_webhook.test", "http_method", "PUT"),
+					resource.TestCheckResourceAttr("bitbucketserver_repository_webhook.test", "username", "admin"),
+					resource.TestCheckResourceAttr("bitbucketserver_repository_webhook.test", "custom_headers.%", "1"),
+					resource.TestCheckResourceAttr("bitbucketserver_repository_webhook.test", "custom_headers.X-Team", "platform"),
+					resource.TestCheckResourceAttrSet("bitbucketserver_repository_webhook.test", "webhook_id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccBitbucketResourceRepositoryWebhook_events(t *testing.T) {
+	projectKey := fmt.Sprintf("TEST%v", rand.New(rand.NewSource(time.Now().UnixNano())).Int())
+	configString := `
+		%s
+
+		resource "bitbucketserver_repository_webhook" "test" {
+			project     = bitbucketserver_project.test.key
+			repository  = bitbucketserver_repository.test.slug
+			name        = "events"
+			webhook_url = "https://www.google.com/"
+			events      = %s
+		}
+	`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      fmt.Sprintf(configString, baseConfigForRepositoryBasedTests(projectKey), `["pr:open"]`),
+				ExpectError: regexp.MustCompile(`expected events\.\d+ to be one of`),
+			},
+			{
+				Config: fmt.Sprintf(configString, baseConfigForRepositoryBasedTests(projectKey), `["pr:merged", "repo:refs_changed", "pr:opened"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucketserver_repository_webhook.test", "events.#", "3"),
+					resource.TestCheckTypeSetElemAttr("bitbucketserver_repository_webhook.test", "events.*", "pr:opened"),
+					resource.TestCheckTypeSetElemAttr("bitbucketserver_repository_webhook.test", "events.*", "pr:merged"),
+					resource.TestCheckTypeSetElemAttr("bitbucketserver_repository_webhook.test", "events.*", "repo:refs_changed"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,273 @@
+package bitbucket
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/xvlcwk-terraform/terraform-provider-bitbucketserver/bitbucket/util/client"
+	bitbucketTypes "github.com/xvlcwk-terraform/terraform-provider-bitbucketserver/bitbucket/util/types"
+	"io/ioutil"
+	"log"
+	"net/url"
+	"strconv"
+)
+
+type RequiredBuildsConditionPayload struct {
+	BuildParentKeys  []string `json:"buildParentKeys"`
+	RefMatcher       Matcher  `json:"refMatcher"`
+	ExemptRefMatcher *Matcher `json:"exemptRefMatcher,omitempty"`
+}
+
+type RequiredBuildsConditionResp struct {
+	ID               int         `json:"id,omitempty"`
+	BuildParentKeys  []string    `json:"buildParentKeys,omitempty"`
+	RefMatcher       RefMatcher  `json:"refMatcher,omitempty"`
+	ExemptRefMatcher *RefMatcher `json:"exemptRefMatcher,omitempty"`
+}
+
+var requiredBuildsMatcherDesc = `id can be either "any" to match all branches, "refs/heads/master" to match certain branch, "pattern" to match multiple branches, "development" to match a branching model branch or "FEATURE" to match a branching model category. type_id must be one of: "ANY_REF", "BRANCH", "PATTERN", "MODEL_BRANCH", "MODEL_CATEGORY".`
+
+var validRequiredBuildsMatcherTypeIDs = []string{
+	"ANY_REF", "BRANCH", "PATTERN", "MODEL_BRANCH", "MODEL_CATEGORY",
+}
+
+func resourceRequiredBuildsCondition() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceRequiredBuildsConditionCreate,
+		Read:   resourceRequiredBuildsConditionRead,
+		Update: resourceRequiredBuildsConditionUpdate,
+		Delete: resourceRequiredBuildsConditionDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"project_key": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"repository_slug": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"build_parent_keys": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Required:    true,
+				MinItems:    1,
+				Description: "Keys of the builds which must succeed before a pull request can be merged.",
+			},
+			"ref_matcher": {
+				Type: schema.TypeMap,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Required:     true,
+				ValidateFunc: validateRequiredBuildsMatcher,
+				Description:  "Target branches of pull requests the condition applies to. " + requiredBuildsMatcherDesc,
+			},
+			"exempt_ref_matcher": {
+				Type: schema.TypeMap,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional:     true,
+				ValidateFunc: validateRequiredBuildsMatcher,
+				Description:  "Branches exempt from the condition. " + requiredBuildsMatcherDesc,
+			},
+		},
+	}
+}
+
+// validateRequiredBuildsMatcher makes sure a matcher has exactly the keys expandMatcher relies on.
+func validateRequiredBuildsMatcher(i interface{}, k string) ([]string, []error) {
+	matcher, ok := i.(map[string]interface{})
+	if !ok {
+		return nil, []error{fmt.Errorf("expected %s to be a map", k)}
+	}
+
+	// an empty exempt_ref_matcher is the same as none
+	if len(matcher) == 0 {
+		return nil, nil
+	}
+
+	var errs []error
+	for _, key := range []string{"id", "type_id"} {
+		if value, ok := matcher[key].(string); !ok || value == "" {
+			errs = append(errs, fmt.Errorf("%s.%s is required", k, key))
+		}
+	}
+
+	for key := range matcher {
+		if key != "id" && key != "type_id" {
+			errs = append(errs, fmt.Errorf("%s.%s is not supported, only id and type_id can be set", k, key))
+		}
+	}
+
+	return nil, errs
+}
+
+func getRequiredBuildsConditionsURI(projectKey string, repositorySlug string) string {
+	if repositorySlug == "" {
+		return fmt.Sprintf("/rest/required-builds/latest/projects/%s",
+			url.PathEscape(projectKey),
+		)
+	}
+
+	return fmt.Sprintf("/rest/required-builds/latest/projects/%s/repos/%s",
+		url.PathEscape(projectKey),
+		url.PathEscape(repositorySlug),
+	)
+}
+
+func newRequiredBuildsConditionFromResource(d *schema.ResourceData) (*RequiredBuildsConditionPayload, error) {
+	payload := &RequiredBuildsConditionPayload{
+		RefMatcher: expandMatcher(d.Get("ref_matcher").(map[string]interface{})),
+	}
+
+	if !contains(validRequiredBuildsMatcherTypeIDs, payload.RefMatcher.Type.ID) {
+		return nil, fmt.Errorf("ref_matcher.type_id %s must be one of %v", payload.RefMatcher.Type.ID, validRequiredBuildsMatcherTypeIDs)
+	}
+
+	exemptRefMatcher := d.Get("exempt_ref_matcher").(map[string]interface{})
+	if len(exemptRefMatcher) > 0 {
+		matcher := expandMatcher(exemptRefMatcher)
+		if !contains(validRequiredBuildsMatcherTypeIDs, matcher.Type.ID) {
+			return nil, fmt.Errorf("exempt_ref_matcher.type_id %s must be one of %v", matcher.Type.ID, validRequiredBuildsMatcherTypeIDs)
+		}
+		payload.ExemptRefMatcher = &matcher
+	}
+
+	for _, key := range d.Get("build_parent_keys").(*schema.Set).List() {
+		payload.BuildParentKeys = append(payload.BuildParentKeys, key.(string))
+	}
+
+	return payload, nil
+}
+
+func resourceRequiredBuildsConditionCreate(d *schema.ResourceData, m interface{}) error {
+	projectKey := d.Get("project_key").(string)
+	repositorySlug := d.Get("repository_slug").(string)
+
+	payload, err := newRequiredBuildsConditionFromResource(d)
+	if err != nil {
+		return err
+	}
+
+	bytedata, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+
+	resp, err := client.Post(getRequiredBuildsConditionsURI(projectKey, repositorySlug)+"/condition", bytes.NewBuffer(bytedata))
+	if err != nil {
+		return err
+	}
+
+	var newCondition RequiredBuildsConditionResp
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(body, &newCondition)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(createResourceID(newCondition.ID, projectKey, repositorySlug))
+
+	return resourceRequiredBuildsConditionRead(d, m)
+}
+
+func resourceRequiredBuildsConditionUpdate(d *schema.ResourceData, m interface{}) error {
+	conditionID, projectKey, repositorySlug, err := parseResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	payload, err := newRequiredBuildsConditionFromResource(d)
+	if err != nil {
+		return err
+	}
+
+	bytedata, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+
+	_, err = client.Put(fmt.Sprintf("%s/condition/%s",
+		getRequiredBuildsConditionsURI(projectKey, repositorySlug),
+		url.PathEscape(conditionID),
+	), bytes.NewBuffer(bytedata))
+	if err != nil {
+		return err
+	}
+
+	return resourceRequiredBuildsConditionRead(d, m)
+}
+
+func resourceRequiredBuildsConditionRead(d *schema.ResourceData, m interface{}) error {
+	conditionID, projectKey, repositorySlug, err := parseResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+
+	conditions, err := readRequiredBuildsConditions(client, projectKey, repositorySlug)
+	if err != nil {
+		return err
+	}
+
+	for _, condition := range conditions {
+		if strconv.Itoa(condition.ID) != conditionID {
+			continue
+		}
+
+		_ = d.Set("project_key", projectKey)
+		_ = d.Set("repository_slug", repositorySlug)
+		_ = d.Set("build_parent_keys", condition.BuildParentKeys)
+		_ = d.Set("ref_matcher", collapseMatcher(refMatcherToMatcher(condition.RefMatcher)))
+		if condition.ExemptRefMatcher != nil && condition.ExemptRefMatcher.ID != "" {
+			_ = d.Set("exempt_ref_matcher", collapseMatcher(refMatcherToMatcher(*condition.ExemptRefMatcher)))
+		} else {
+			_ = d.Set("exempt_ref_matcher", nil)
+		}
+
+		return nil
+	}
+
+	log.Printf("[WARN] Required builds condition %s not found, removing from state", d.Id())
+	d.SetId("")
+	return nil
+}
+
+func readRequiredBuildsConditions(client *client.BitbucketClient, projectKey string, repositorySlug string) ([]RequiredBuildsConditionResp, error) {
+	return readAllPages[RequiredBuildsConditionResp](client, getRequiredBuildsConditionsURI(projectKey, repositorySlug)+"/conditions")
+}
+
+func resourceRequiredBuildsConditionDelete(d *schema.ResourceData, m interface{}) error {
+	conditionID, projectKey, repositorySlug, err := parseResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+
+	_, err = client.Delete(fmt.Sprintf("%s/condition/%s",
+		getRequiredBuildsConditionsURI(projectKey, repositorySlug),
+		url.PathEscape(conditionID),
+	))
+
+	return err
+}
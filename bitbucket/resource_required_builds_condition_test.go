@@ -0,0 +1,71 @@
+package bitbucket
+
+import (
+	"fmt"
+	"math/rand"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccBitbucketRequiredBuildsCondition_forRepository(t *testing.T) {
+	projectKey := fmt.Sprintf("TEST%v", rand.New(rand.NewSource(time.Now().UnixNano())).Int())
+
+	configTemplate := baseConfigForRepositoryBasedTests(projectKey) + `
+		resource "bitbucketserver_required_builds_condition" "test" {
+			project_key       = bitbucketserver_project.test.key
+			repository_slug   = bitbucketserver_repository.test.slug
+			build_parent_keys = %s
+			ref_matcher = {
+				id      = "refs/heads/master"
+				type_id = "BRANCH"
+			}
+			exempt_ref_matcher = {
+				id      = "release/*"
+				type_id = "PATTERN"
+			}
+		}
+	`
+
+	config := fmt.Sprintf(configTemplate, `["ci-build"]`)
+	configUpdated := fmt.Sprintf(configTemplate, `["ci-build", "ci-lint"]`)
+	configIncompleteMatcher := strings.Replace(config, `type_id = "BRANCH"`, "", 1)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      configIncompleteMatcher,
+				ExpectError: regexp.MustCompile("ref_matcher.type_id is required"),
+			},
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucketserver_required_builds_condition.test", "project_key", projectKey),
+					resource.TestCheckResourceAttr("bitbucketserver_required_builds_condition.test", "repository_slug", "repo"),
+					resource.TestCheckResourceAttr("bitbucketserver_required_builds_condition.test", "build_parent_keys.#", "1"),
+					resource.TestCheckResourceAttr("bitbucketserver_required_builds_condition.test", "ref_matcher.id", "refs/heads/master"),
+					resource.TestCheckResourceAttr("bitbucketserver_required_builds_condition.test", "ref_matcher.type_id", "BRANCH"),
+					resource.TestCheckResourceAttr("bitbucketserver_required_builds_condition.test", "exempt_ref_matcher.id", "release/*"),
+					resource.TestCheckResourceAttr("bitbucketserver_required_builds_condition.test", "exempt_ref_matcher.type_id", "PATTERN"),
+				),
+			},
+			{
+				Config: configUpdated,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucketserver_required_builds_condition.test", "build_parent_keys.#", "2"),
+					resource.TestCheckTypeSetElemAttr("bitbucketserver_required_builds_condition.test", "build_parent_keys.*", "ci-lint"),
+				),
+			},
+			{
+				ResourceName:      "bitbucketserver_required_builds_condition.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,256 @@
+package bitbucket
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/xvlcwk-terraform/terraform-provider-bitbucketserver/bitbucket/util/client"
+	bitbucketTypes "github.com/xvlcwk-terraform/terraform-provider-bitbucketserver/bitbucket/util/types"
+	"io/ioutil"
+	"log"
+	"net/url"
+	"strconv"
+)
+
+type ReviewerGroupUser struct {
+	ID   int    `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+	Slug string `json:"slug,omitempty"`
+}
+
+type ReviewerGroup struct {
+	ID          int                 `json:"id,omitempty"`
+	Name        string              `json:"name,omitempty"`
+	Description string              `json:"description"`
+	Users       []ReviewerGroupUser `json:"users"`
+}
+
+func resourceReviewerGroup() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceReviewerGroupCreate,
+		Read:   resourceReviewerGroupRead,
+		Update: resourceReviewerGroupUpdate,
+		Delete: resourceReviewerGroupDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"project_key": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"repository_slug": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"users": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Required:    true,
+				MinItems:    1,
+				Description: "Slugs of the users in the reviewer group.",
+			},
+			"reviewer_group_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func getReviewerGroupsURI(projectKey string, repositorySlug string) string {
+	if repositorySlug == "" {
+		return fmt.Sprintf("/rest/api/latest/projects/%s/settings/reviewer-groups",
+			url.PathEscape(projectKey),
+		)
+	}
+
+	return fmt.Sprintf("/rest/api/latest/projects/%s/repos/%s/settings/reviewer-groups",
+		url.PathEscape(projectKey),
+		url.PathEscape(repositorySlug),
+	)
+}
+
+func newReviewerGroupFromResource(client *client.BitbucketClient, d *schema.ResourceData) (*ReviewerGroup, error) {
+	reviewerGroup := &ReviewerGroup{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+	}
+
+	// name and slug of a user differ e.g. for capitals or special characters, the user is looked up to send both correctly
+	for _, slug := range d.Get("users").(*schema.Set).List() {
+		resp, err := client.Get(fmt.Sprintf("/rest/api/1.0/users/%s",
+			url.PathEscape(slug.(string)),
+		))
+
+		if resp != nil && resp.StatusCode == 404 {
+			return nil, fmt.Errorf("user %s of reviewer group %s not found", slug, reviewerGroup.Name)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		var user ReviewerGroupUser
+
+		decoder := json.NewDecoder(resp.Body)
+		err = decoder.Decode(&user)
+		if err != nil {
+			return nil, err
+		}
+
+		reviewerGroup.Users = append(reviewerGroup.Users, user)
+	}
+
+	return reviewerGroup, nil
+}
+
+func resourceReviewerGroupCreate(d *schema.ResourceData, m interface{}) error {
+	projectKey := d.Get("project_key").(string)
+	repositorySlug := d.Get("repository_slug").(string)
+	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+
+	reviewerGroup, err := newReviewerGroupFromResource(client, d)
+	if err != nil {
+		return err
+	}
+
+	bytedata, err := json.Marshal(reviewerGroup)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Post(getReviewerGroupsURI(projectKey, repositorySlug), bytes.NewBuffer(bytedata))
+	if err != nil {
+		return err
+	}
+
+	var newReviewerGroup ReviewerGroup
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(body, &newReviewerGroup)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(createResourceID(newReviewerGroup.ID, projectKey, repositorySlug))
+
+	return resourceReviewerGroupRead(d, m)
+}
+
+func resourceReviewerGroupUpdate(d *schema.ResourceData, m interface{}) error {
+	reviewerGroupID, projectKey, repositorySlug, err := parseResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+
+	reviewerGroup, err := newReviewerGroupFromResource(client, d)
+	if err != nil {
+		return err
+	}
+
+	bytedata, err := json.Marshal(reviewerGroup)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Put(fmt.Sprintf("%s/%s",
+		getReviewerGroupsURI(projectKey, repositorySlug),
+		url.PathEscape(reviewerGroupID),
+	), bytes.NewBuffer(bytedata))
+	if err != nil {
+		return err
+	}
+
+	return resourceReviewerGroupRead(d, m)
+}
+
+func resourceReviewerGroupRead(d *schema.ResourceData, m interface{}) error {
+	reviewerGroupID, projectKey, repositorySlug, err := parseResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+
+	resp, err := client.Get(fmt.Sprintf("%s/%s",
+		getReviewerGroupsURI(projectKey, repositorySlug),
+		url.PathEscape(reviewerGroupID),
+	))
+
+	if resp != nil && resp.StatusCode == 404 {
+		log.Printf("[WARN] Reviewer group %s not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	var reviewerGroup ReviewerGroup
+
+	decoder := json.NewDecoder(resp.Body)
+	err = decoder.Decode(&reviewerGroup)
+	if err != nil {
+		return err
+	}
+
+	id, _ := strconv.Atoi(reviewerGroupID)
+	_ = d.Set("project_key", projectKey)
+	_ = d.Set("repository_slug", repositorySlug)
+	_ = d.Set("reviewer_group_id", id)
+	_ = d.Set("name", reviewerGroup.Name)
+	_ = d.Set("description", reviewerGroup.Description)
+	_ = d.Set("users", collapseReviewerGroupUsers(reviewerGroup.Users))
+
+	return nil
+}
+
+func resourceReviewerGroupDelete(d *schema.ResourceData, m interface{}) error {
+	reviewerGroupID, projectKey, repositorySlug, err := parseResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+
+	_, err = client.Delete(fmt.Sprintf("%s/%s",
+		getReviewerGroupsURI(projectKey, repositorySlug),
+		url.PathEscape(reviewerGroupID),
+	))
+
+	return err
+}
+
+func collapseReviewerGroupUsers(users []ReviewerGroupUser) []string {
+	slugs := make([]string, 0, len(users))
+	for _, user := range users {
+		slugs = append(slugs, user.Slug)
+	}
+
+	return slugs
+}
+
+func readReviewerGroups(client *client.BitbucketClient, projectKey string, repositorySlug string) ([]ReviewerGroup, error) {
+	return readAllPages[ReviewerGroup](client, getReviewerGroupsURI(projectKey, repositorySlug))
+}
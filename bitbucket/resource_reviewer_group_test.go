@@ -0,0 +1,66 @@
+package bitbucket
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccBitbucketResourceReviewerGroup_forProject(t *testing.T) {
+	projectKey := fmt.Sprintf("TEST%v", rand.New(rand.NewSource(time.Now().UnixNano())).Int())
+
+	configTemplate := `
+		resource "bitbucketserver_project" "test" {
+			key  = "%v"
+			name = "test-project-%v"
+		}
+
+		resource "bitbucketserver_user" "mreynolds" {
+			name          = "mreynolds"
+			display_name  = "Malcolm Reynolds"
+			email_address = "browncoat@example.com"
+		}
+
+		resource "bitbucketserver_reviewer_group" "test" {
+			project_key = bitbucketserver_project.test.key
+			name        = "serenity"
+			description = "%v"
+			users       = ["admin", bitbucketserver_user.mreynolds.name]
+		}
+	`
+
+	config := fmt.Sprintf(configTemplate, projectKey, projectKey, "The crew")
+	configUpdated := fmt.Sprintf(configTemplate, projectKey, projectKey, "The whole crew")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucketserver_reviewer_group.test", "project_key", projectKey),
+					resource.TestCheckResourceAttr("bitbucketserver_reviewer_group.test", "name", "serenity"),
+					resource.TestCheckResourceAttr("bitbucketserver_reviewer_group.test", "description", "The crew"),
+					resource.TestCheckResourceAttr("bitbucketserver_reviewer_group.test", "users.#", "2"),
+					resource.TestCheckTypeSetElemAttr("bitbucketserver_reviewer_group.test", "users.*", "mreynolds"),
+					resource.TestCheckResourceAttrSet("bitbucketserver_reviewer_group.test", "reviewer_group_id"),
+				),
+			},
+			{
+				Config: configUpdated,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucketserver_reviewer_group.test", "description", "The whole crew"),
+				),
+			},
+			{
+				ResourceName:      "bitbucketserver_reviewer_group.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
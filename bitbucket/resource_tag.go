@@ -0,0 +1,175 @@
+package bitbucket
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	bitbucketTypes "github.com/xvlcwk-terraform/terraform-provider-bitbucketserver/bitbucket/util/types"
+	"log"
+	"net/url"
+)
+
+func resourceTag() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceTagCreate,
+		Read:   resourceTagRead,
+		Update: resourceTagUpdate,
+		Delete: resourceTagDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceTagImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"repository": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the tag, without the refs/tags/ prefix.",
+			},
+			"start_point": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Branch, tag or commit to tag. Only used when the tag is created.",
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return d.Id() != ""
+				},
+			},
+			"message": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Message of an annotated tag, a lightweight tag is created if omitted.",
+			},
+			"deletion_protection": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Refuse to delete the tag when the resource is destroyed.",
+			},
+			"latest_commit": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"hash": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func getGitTagsURI(project string, repoSlug string) string {
+	return fmt.Sprintf("/rest/git/latest/projects/%s/repos/%s/tags",
+		url.PathEscape(project),
+		url.PathEscape(repoSlug),
+	)
+}
+
+func resourceTagCreate(d *schema.ResourceData, m interface{}) error {
+	project := d.Get("project").(string)
+	repository := d.Get("repository").(string)
+	name := d.Get("name").(string)
+
+	request, err := json.Marshal(&GitRefCreateRequest{
+		Name:       name,
+		StartPoint: d.Get("start_point").(string),
+		Message:    d.Get("message").(string),
+	})
+	if err != nil {
+		return err
+	}
+
+	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+	_, err = client.Post(getGitTagsURI(project, repository), bytes.NewBuffer(request))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", project, repository, name))
+
+	return resourceTagRead(d, m)
+}
+
+func resourceTagUpdate(d *schema.ResourceData, m interface{}) error {
+	// a tag can't be moved or renamed, nothing needs to be sent to Bitbucket
+	return resourceTagRead(d, m)
+}
+
+func resourceTagRead(d *schema.ResourceData, m interface{}) error {
+	project, repository, name, err := parseGitRefID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+	resp, err := client.Get(fmt.Sprintf("%s/%s",
+		getRepositoryRefsURI(project, repository, "tags"),
+		escapeFilePath(name),
+	))
+
+	if resp != nil && resp.StatusCode == 404 {
+		log.Printf("[WARN] Tag %s not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	var tag GitRef
+
+	decoder := json.NewDecoder(resp.Body)
+	err = decoder.Decode(&tag)
+	if err != nil {
+		return err
+	}
+
+	_ = d.Set("project", project)
+	_ = d.Set("repository", repository)
+	_ = d.Set("name", tag.DisplayID)
+	_ = d.Set("latest_commit", tag.LatestCommit)
+	_ = d.Set("hash", tag.Hash)
+
+	return nil
+}
+
+func resourceTagImport(d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	// the protection isn't known to Bitbucket, an imported tag starts unprotected
+	_ = d.Set("deletion_protection", false)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceTagDelete(d *schema.ResourceData, m interface{}) error {
+	if d.Get("deletion_protection").(bool) {
+		return fmt.Errorf("tag %s is protected from deletion, set deletion_protection = false and apply before destroying it", d.Id())
+	}
+
+	project, repository, name, err := parseGitRefID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+	resp, err := client.Delete(fmt.Sprintf("%s/%s",
+		getGitTagsURI(project, repository),
+		escapeFilePath(name),
+	))
+	if resp != nil && resp.StatusCode == 404 {
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,72 @@
+package bitbucket
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccBitbucketResourceTag(t *testing.T) {
+	projectKey := fmt.Sprintf("TEST%v", rand.New(rand.NewSource(time.Now().UnixNano())).Int())
+
+	config := fmt.Sprintf(`
+		resource "bitbucketserver_project" "test" {
+			key  = "%v"
+			name = "test-project-%v"
+		}
+
+		resource "bitbucketserver_repository" "test" {
+			project        = bitbucketserver_project.test.key
+			name           = "repo"
+			default_branch = "main"
+
+			initialize {
+				readme = "# repo"
+			}
+		}
+
+		resource "bitbucketserver_tag" "test" {
+			project     = bitbucketserver_project.test.key
+			repository  = bitbucketserver_repository.test.slug
+			name        = "v1.0.0"
+			start_point = "main"
+			message     = "Release 1.0.0"
+			deletion_protection = true
+		}
+	`, projectKey, projectKey)
+
+	configUnprotected := strings.ReplaceAll(config, "deletion_protection = true", "deletion_protection = false")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucketserver_tag.test", "id", projectKey+"/repo/v1.0.0"),
+					resource.TestCheckResourceAttr("bitbucketserver_tag.test", "name", "v1.0.0"),
+					resource.TestCheckResourceAttrSet("bitbucketserver_tag.test", "latest_commit"),
+					resource.TestCheckResourceAttrSet("bitbucketserver_tag.test", "hash"),
+				),
+			},
+			{
+				ResourceName:            "bitbucketserver_tag.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"start_point", "message", "deletion_protection"},
+			},
+			{
+				// the protection has to be lifted before the tag can be destroyed
+				Config: configUnprotected,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucketserver_tag.test", "deletion_protection", "false"),
+				),
+			},
+		},
+	})
+}
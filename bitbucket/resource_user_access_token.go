@@ -2,16 +2,20 @@ package bitbucket
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	bitbucketTypes "github.com/xvlcwk-terraform/terraform-provider-bitbucketserver/bitbucket/util/types"
 	"io/ioutil"
+	"time"
 )
 
 type AccessTokenRequest struct {
 	Name        string        `json:"name,omitempty"`
 	Permissions []interface{} `json:"permissions,omitempty"`
+	ExpiryDays  int           `json:"expiryDays,omitempty"`
 }
 
 type AccessTokenResponse struct {
@@ -21,15 +25,18 @@ type AccessTokenResponse struct {
 	Name              string   `json:"name,omitempty"`
 	Permissions       []string `json:"permissions,omitempty"`
 	Token             string   `json:"token,omitempty"`
+	ExpiryDays        int      `json:"expiryDays,omitempty"`
+	ExpiryDate        jsonTime `json:"expiryDate,omitempty"`
 }
 
 func resourceUserAccessToken() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceUserAccessTokenCreate,
-		Update: resourceUserAccessTokenUpdate,
-		Read:   resourceUserAccessTokenRead,
-		Exists: resourceUserAccessTokenExists,
-		Delete: resourceUserAccessTokenDelete,
+		Create:        resourceUserAccessTokenCreate,
+		Update:        resourceUserAccessTokenUpdate,
+		Read:          resourceUserAccessTokenRead,
+		Exists:        resourceUserAccessTokenExists,
+		Delete:        resourceUserAccessTokenDelete,
+		CustomizeDiff: resourceUserAccessTokenCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -49,10 +56,34 @@ func resourceUserAccessToken() *schema.Resource {
 				Required: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
+			"expire_in": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Expire in X Days. If not set the expiry configured in Bitbucket applies.",
+			},
+			"rotate_before_days": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Replace the token during plan once it expires within this many days.",
+			},
+			"keepers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary values which replace the token when they change.",
+			},
 			"created_date": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"expiry_date": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"last_authenticated": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -72,6 +103,7 @@ func resourceUserAccessTokenCreate(d *schema.ResourceData, m interface{}) error
 	accessTokenRequest := &AccessTokenRequest{
 		Name:        d.Get("name").(string),
 		Permissions: d.Get("permissions").([]interface{}),
+		ExpiryDays:  d.Get("expire_in").(int),
 	}
 
 	byteData, err := json.Marshal(accessTokenRequest)
@@ -157,9 +189,41 @@ func resourceUserAccessTokenRead(d *schema.ResourceData, m interface{}) error {
 	_ = d.Set("created_date", accessTokenResponse.CreatedDate.String())
 	_ = d.Set("last_authenticated", accessTokenResponse.LastAuthenticated.String())
 
+	if time.Time(accessTokenResponse.ExpiryDate).IsZero() {
+		_ = d.Set("expiry_date", "")
+	} else {
+		_ = d.Set("expiry_date", accessTokenResponse.ExpiryDate.String())
+	}
+
 	return nil
 }
 
+// resourceUserAccessTokenCustomizeDiff replaces the token once it is within rotate_before_days of its expiry.
+func resourceUserAccessTokenCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	rotateBeforeDays := d.Get("rotate_before_days").(int)
+	expiryDate := d.Get("expiry_date").(string)
+	if d.Id() == "" || rotateBeforeDays == 0 || expiryDate == "" {
+		return nil
+	}
+
+	expiry, err := time.Parse(jsonTimeLayout, expiryDate)
+	if err != nil {
+		return fmt.Errorf("failed to parse expiry_date %s: %v", expiryDate, err)
+	}
+
+	if time.Now().Before(expiry.AddDate(0, 0, -rotateBeforeDays)) {
+		return nil
+	}
+
+	// Terraform only replaces a resource if an attribute forcing a new resource changes
+	err = d.SetNewComputed("expiry_date")
+	if err != nil {
+		return err
+	}
+
+	return d.ForceNew("expiry_date")
+}
+
 func resourceUserAccessTokenExists(d *schema.ResourceData, m interface{}) (bool, error) {
 	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
 	req, err := client.Get(fmt.Sprintf("/rest/access-tokens/1.0/users/%s/%s",
@@ -53,6 +53,62 @@ func TestAccBitbucketUserAccessToken(t *testing.T) {
 	})
 }
 
+func TestAccBitbucketUserAccessToken_rotation(t *testing.T) {
+	configString := `
+		resource "bitbucketserver_user_access_token" "test" {
+			user               = "admin"
+			name               = "rotated-token"
+			permissions        = ["REPO_READ"]
+			expire_in          = 30
+			rotate_before_days = %d
+			keepers = {
+				generation = "%s"
+			}
+		}
+	`
+
+	var tokenId string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBitbucketUserAccessTokenDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(configString, 7, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("bitbucketserver_user_access_token.test", "expiry_date"),
+					testAccStoreUserAccessTokenId(&tokenId),
+				),
+			},
+			{
+				// a window longer than the validity puts the token into rotation on every plan
+				Config:             fmt.Sprintf(configString, 31, "1"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: fmt.Sprintf(configString, 7, "2"),
+				Check: resource.ComposeTestCheckFunc(
+					func(s *terraform.State) error {
+						if s.RootModule().Resources["bitbucketserver_user_access_token.test"].Primary.ID == tokenId {
+							return fmt.Errorf("access token %s was not replaced after changing the keepers", tokenId)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testAccStoreUserAccessTokenId(tokenId *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		*tokenId = s.RootModule().Resources["bitbucketserver_user_access_token.test"].Primary.ID
+		return nil
+	}
+}
+
 func testAccCheckBitbucketUserAccessTokenDestroy(s *terraform.State) error {
 	client := testAccProvider.Meta().(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
 	rs, ok := s.RootModule().Resources["bitbucketserver_user_access_token.test"]
@@ -0,0 +1,165 @@
+package bitbucket
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/xvlcwk-terraform/terraform-provider-bitbucketserver/bitbucket/util/client"
+	bitbucketTypes "github.com/xvlcwk-terraform/terraform-provider-bitbucketserver/bitbucket/util/types"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type UserGPGKey struct {
+	ID           string   `json:"id,omitempty"`
+	Text         string   `json:"text,omitempty"`
+	Fingerprint  string   `json:"fingerprint,omitempty"`
+	EmailAddress string   `json:"emailAddress,omitempty"`
+	ExpiryDate   jsonTime `json:"expiryDate,omitempty"`
+}
+
+type UserGPGKeyRequest struct {
+	Text string `json:"text"`
+}
+
+func resourceUserGPGKey() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceUserGPGKeyCreate,
+		Read:   resourceUserGPGKeyRead,
+		Delete: resourceUserGPGKeyDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"user": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Slug of the user owning the key.",
+			},
+			"key": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ASCII armored public key.",
+				StateFunc: func(val interface{}) string {
+					return strings.TrimSpace(val.(string))
+				},
+			},
+			"key_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"fingerprint": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"email_address": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"expiry_date": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func getUserGPGKeysURI(user string) string {
+	return fmt.Sprintf("/rest/gpg/1.0/keys?user=%s", url.QueryEscape(user))
+}
+
+func resourceUserGPGKeyCreate(d *schema.ResourceData, m interface{}) error {
+	user := d.Get("user").(string)
+
+	request, err := json.Marshal(&UserGPGKeyRequest{
+		Text: strings.TrimSpace(d.Get("key").(string)),
+	})
+	if err != nil {
+		return err
+	}
+
+	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+	resp, err := client.Post(getUserGPGKeysURI(user), bytes.NewBuffer(request))
+	if err != nil {
+		return err
+	}
+
+	var key UserGPGKey
+
+	decoder := json.NewDecoder(resp.Body)
+	err = decoder.Decode(&key)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", user, key.Fingerprint))
+
+	return resourceUserGPGKeyRead(d, m)
+}
+
+func resourceUserGPGKeyRead(d *schema.ResourceData, m interface{}) error {
+	user, fingerprint, err := parseUserKeyID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+
+	keys, err := readUserGPGKeys(client, user)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if !strings.EqualFold(key.Fingerprint, fingerprint) {
+			continue
+		}
+
+		_ = d.Set("user", user)
+		_ = d.Set("key", strings.TrimSpace(key.Text))
+		_ = d.Set("key_id", key.ID)
+		_ = d.Set("fingerprint", key.Fingerprint)
+		_ = d.Set("email_address", key.EmailAddress)
+		_ = d.Set("expiry_date", formatGPGKeyExpiry(key.ExpiryDate))
+
+		return nil
+	}
+
+	log.Printf("[WARN] GPG key %s not found, removing from state", d.Id())
+	d.SetId("")
+	return nil
+}
+
+func resourceUserGPGKeyDelete(d *schema.ResourceData, m interface{}) error {
+	user, fingerprint, err := parseUserKeyID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+	_, err = client.Delete(fmt.Sprintf("/rest/gpg/1.0/keys/%s?user=%s",
+		url.PathEscape(fingerprint),
+		url.QueryEscape(user),
+	))
+
+	return err
+}
+
+// formatGPGKeyExpiry returns an empty string for keys which never expire.
+func formatGPGKeyExpiry(expiryDate jsonTime) string {
+	if time.Time(expiryDate).IsZero() || time.Time(expiryDate).Unix() == 0 {
+		return ""
+	}
+
+	return expiryDate.String()
+}
+
+func readUserGPGKeys(client *client.BitbucketClient, user string) ([]UserGPGKey, error) {
+	return readAllPages[UserGPGKey](client, getUserGPGKeysURI(user))
+}
@@ -0,0 +1,65 @@
+package bitbucket
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const testAccUserGPGKey = `-----BEGIN PGP PUBLIC KEY BLOCK-----
+
+mDMEatY5OxYJKwYBBAHaRw8BAQdAWvfoJdrw8eL9vFKuBdqe+ztu6wcTD2S9Zl8n
+yHI6tla0K1RlcnJhZm9ybSBUZXN0IDx0ZXJyYWZvcm0tdGVzdEBleGFtcGxlLmNv
+bT6IkAQTFggAOBYhBJjz/f2sAx5kL7nqpecW0U16nMk2BQJq1jk7AhsDBQsJCAcC
+BhUKCQgLAgQWAgMBAh4BAheAAAoJEOcW0U16nMk2FnABAKnf6+ngZJisb5PeVaMC
+g/C/xxqyXl+70B0x5pN5x4WGAQCRSdIQZyCyKQEfLKlc+LZ9EKiLrGUdCAr735kd
+wP0vDA==
+=6rIV
+-----END PGP PUBLIC KEY BLOCK-----`
+
+func TestAccBitbucketResourceUserGPGKey(t *testing.T) {
+	userRand := fmt.Sprintf("%v", rand.New(rand.NewSource(time.Now().UnixNano())).Int())
+	config := fmt.Sprintf(`
+		resource "bitbucketserver_user" "test" {
+			name          = "gpgkey%v"
+			display_name  = "GPG Key %v"
+			email_address = "terraform-test@example.com"
+		}
+
+		resource "bitbucketserver_user_gpg_key" "test" {
+			user = bitbucketserver_user.test.name
+			key  = <<EOT
+%s
+EOT
+		}
+
+		data "bitbucketserver_user_gpg_keys" "test" {
+			user = bitbucketserver_user_gpg_key.test.user
+		}
+	`, userRand, userRand, testAccUserGPGKey)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucketserver_user_gpg_key.test", "user", "gpgkey"+userRand),
+					resource.TestCheckResourceAttr("bitbucketserver_user_gpg_key.test", "email_address", "terraform-test@example.com"),
+					resource.TestCheckResourceAttrSet("bitbucketserver_user_gpg_key.test", "fingerprint"),
+					resource.TestCheckResourceAttr("data.bitbucketserver_user_gpg_keys.test", "keys.#", "1"),
+					resource.TestCheckResourceAttrPair("data.bitbucketserver_user_gpg_keys.test", "keys.0.fingerprint", "bitbucketserver_user_gpg_key.test", "fingerprint"),
+				),
+			},
+			{
+				ResourceName:      "bitbucketserver_user_gpg_key.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
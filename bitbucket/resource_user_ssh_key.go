@@ -0,0 +1,177 @@
+package bitbucket
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/xvlcwk-terraform/terraform-provider-bitbucketserver/bitbucket/util/client"
+	bitbucketTypes "github.com/xvlcwk-terraform/terraform-provider-bitbucketserver/bitbucket/util/types"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+type UserSSHKey struct {
+	ID            int    `json:"id,omitempty"`
+	Text          string `json:"text,omitempty"`
+	Label         string `json:"label,omitempty"`
+	ExpiryDays    int    `json:"expiryDays,omitempty"`
+	AlgorithmType string `json:"algorithmType,omitempty"`
+	BitLength     int    `json:"bitLength,omitempty"`
+	Fingerprint   string `json:"fingerprint,omitempty"`
+}
+
+func resourceUserSSHKey() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceUserSSHKeyCreate,
+		Read:   resourceUserSSHKeyRead,
+		Delete: resourceUserSSHKeyDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"user": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Slug of the user owning the key.",
+			},
+			"key": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				StateFunc: func(val interface{}) string {
+					return strings.TrimSpace(val.(string))
+				},
+			},
+			"label": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"expiry_days": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"key_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"fingerprint": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"algorithm": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"bit_length": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func getUserSSHKeysURI(user string) string {
+	return fmt.Sprintf("/rest/ssh/1.0/keys?user=%s", url.QueryEscape(user))
+}
+
+func resourceUserSSHKeyCreate(d *schema.ResourceData, m interface{}) error {
+	user := d.Get("user").(string)
+
+	request, err := json.Marshal(&UserSSHKey{
+		Text:       strings.TrimSpace(d.Get("key").(string)),
+		Label:      d.Get("label").(string),
+		ExpiryDays: d.Get("expiry_days").(int),
+	})
+	if err != nil {
+		return err
+	}
+
+	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+	resp, err := client.Post(getUserSSHKeysURI(user), bytes.NewBuffer(request))
+	if err != nil {
+		return err
+	}
+
+	var key UserSSHKey
+
+	decoder := json.NewDecoder(resp.Body)
+	err = decoder.Decode(&key)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%d", user, key.ID))
+
+	return resourceUserSSHKeyRead(d, m)
+}
+
+func resourceUserSSHKeyRead(d *schema.ResourceData, m interface{}) error {
+	user, keyID, err := parseUserKeyID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+
+	keys, err := readUserSSHKeys(client, user)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if strconv.Itoa(key.ID) != keyID {
+			continue
+		}
+
+		_ = d.Set("user", user)
+		_ = d.Set("key", strings.TrimSpace(key.Text))
+		_ = d.Set("label", key.Label)
+		_ = d.Set("expiry_days", key.ExpiryDays)
+		_ = d.Set("key_id", key.ID)
+		_ = d.Set("fingerprint", key.Fingerprint)
+		_ = d.Set("algorithm", key.AlgorithmType)
+		_ = d.Set("bit_length", key.BitLength)
+
+		return nil
+	}
+
+	log.Printf("[WARN] SSH key %s not found, removing from state", d.Id())
+	d.SetId("")
+	return nil
+}
+
+func resourceUserSSHKeyDelete(d *schema.ResourceData, m interface{}) error {
+	_, keyID, err := parseUserKeyID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	client := m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient
+	_, err = client.Delete(fmt.Sprintf("/rest/ssh/1.0/keys/%s", url.PathEscape(keyID)))
+
+	return err
+}
+
+// parseUserKeyID splits the `user/key` ID of SSH and GPG keys. User slugs can't contain a slash, key ids and fingerprints neither.
+func parseUserKeyID(id string) (user string, keyID string, err error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("incorrect ID format, should match `user/key_id`")
+	}
+
+	return parts[0], parts[1], nil
+}
+
+func readUserSSHKeys(client *client.BitbucketClient, user string) ([]UserSSHKey, error) {
+	return readAllPages[UserSSHKey](client, getUserSSHKeysURI(user))
+}
@@ -0,0 +1,54 @@
+package bitbucket
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccBitbucketResourceUserSSHKey(t *testing.T) {
+	userRand := fmt.Sprintf("%v", rand.New(rand.NewSource(time.Now().UnixNano())).Int())
+	config := fmt.Sprintf(`
+		resource "bitbucketserver_user" "test" {
+			name          = "sshkey%v"
+			display_name  = "SSH Key %v"
+			email_address = "sshkey%v@example.com"
+		}
+
+		resource "bitbucketserver_user_ssh_key" "test" {
+			user  = bitbucketserver_user.test.name
+			label = "service account"
+			key   = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIAdt8390mkoSqWhNLQGhtKr5Hv9Ux77gyh3ce/E8CnSx test@example.com"
+		}
+
+		data "bitbucketserver_user_ssh_keys" "test" {
+			user = bitbucketserver_user_ssh_key.test.user
+		}
+	`, userRand, userRand, userRand)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucketserver_user_ssh_key.test", "user", "sshkey"+userRand),
+					resource.TestCheckResourceAttr("bitbucketserver_user_ssh_key.test", "label", "service account"),
+					resource.TestCheckResourceAttrSet("bitbucketserver_user_ssh_key.test", "key_id"),
+					resource.TestCheckResourceAttrSet("bitbucketserver_user_ssh_key.test", "fingerprint"),
+					resource.TestCheckResourceAttr("data.bitbucketserver_user_ssh_keys.test", "keys.#", "1"),
+					resource.TestCheckResourceAttrPair("data.bitbucketserver_user_ssh_keys.test", "keys.0.fingerprint", "bitbucketserver_user_ssh_key.test", "fingerprint"),
+				),
+			},
+			{
+				ResourceName:      "bitbucketserver_user_ssh_key.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -3,13 +3,19 @@ package util
 import (
 	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/xvlcwk-terraform/terraform-provider-bitbucketserver/bitbucket/util/client"
+	"time"
 )
 
 type (
@@ -70,9 +76,32 @@ func (r *AccessTokenResourceHelper) Schema(s map[string]schema.Attribute) map[st
 	}
 	if _, ok := s["expire_in"]; !ok {
 		s["expire_in"] = schema.Int64Attribute{
+			Optional:    true,
 			Computed:    true,
-			Description: "Expire in X Days. If not set it does not expire",
+			Description: "Expire in X Days. Defaults to 1095",
 			Default:     int64default.StaticInt64(1095),
+			PlanModifiers: []planmodifier.Int64{
+				int64planmodifier.RequiresReplace(),
+			},
+		}
+	}
+	if _, ok := s["rotate_before_days"]; !ok {
+		s["rotate_before_days"] = schema.Int64Attribute{
+			Optional:    true,
+			Description: "Replace the token during plan once it expires within this many days",
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
+		}
+	}
+	if _, ok := s["keepers"]; !ok {
+		s["keepers"] = schema.MapAttribute{
+			Optional:    true,
+			Description: "Arbitrary values which replace the token when they change",
+			ElementType: types.StringType,
+			PlanModifiers: []planmodifier.Map{
+				mapplanmodifier.RequiresReplace(),
+			},
 		}
 	}
 	if _, ok := s["created_date"]; !ok {
@@ -84,6 +113,42 @@ func (r *AccessTokenResourceHelper) Schema(s map[string]schema.Attribute) map[st
 	return s
 }
 
+// ModifyPlan replaces the token once it is within rotate_before_days of its expiry.
+func (r *AccessTokenResourceHelper) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to rotate on create and destroy
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var rotateBeforeDays, expireIn, createdDate types.Int64
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("rotate_before_days"), &rotateBeforeDays)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("expire_in"), &expireIn)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("created_date"), &createdDate)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if rotateBeforeDays.IsNull() || rotateBeforeDays.IsUnknown() || createdDate.IsNull() || expireIn.IsNull() || expireIn.ValueInt64() <= 0 {
+		return
+	}
+
+	if !IsRotationDue(time.UnixMilli(createdDate.ValueInt64()), expireIn.ValueInt64(), rotateBeforeDays.ValueInt64()) {
+		return
+	}
+
+	// Terraform only replaces a resource if an attribute marked for replacement changes
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("created_date"), types.Int64Unknown())...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("token"), types.StringUnknown())...)
+	resp.RequiresReplace = append(resp.RequiresReplace, path.Root("created_date"))
+}
+
+// IsRotationDue reports whether a token created at createdDate and valid for expireInDays expires within rotateBeforeDays.
+func IsRotationDue(createdDate time.Time, expireInDays int64, rotateBeforeDays int64) bool {
+	expiry := createdDate.AddDate(0, 0, int(expireInDays))
+	return !time.Now().Before(expiry.AddDate(0, 0, -int(rotateBeforeDays)))
+}
+
 type CreateAccessTokenRequest struct {
 	ExpiryDays  int64    `json:"expiryDays" binding:"required"`
 	Name        string   `json:"name" binding:"required"`
@@ -91,8 +156,10 @@ type CreateAccessTokenRequest struct {
 }
 
 type AccessTokenResponse struct {
-	Token       string `json:"token,omitempty"` // Only available on creation
-	Name        string `json:"name" binding:"required"`
-	Id          string `json:"id" binding:"required"`
-	CreatedDate int64  `json:"createdDate" binding:"required"`
+	Token       string   `json:"token,omitempty"` // Only available on creation
+	Name        string   `json:"name" binding:"required"`
+	Id          string   `json:"id" binding:"required"`
+	CreatedDate int64    `json:"createdDate" binding:"required"`
+	ExpiryDate  int64    `json:"expiryDate,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
 }
@@ -233,6 +233,25 @@ func (c *BitbucketClient) PutOnly(endpoint string) (*http.Response, error) {
 	return c.Do("PUT", endpoint, nil, "application/json")
 }
 
+// PutMultipartForm sends the fields as multipart/form-data, as required e.g. by the file edit endpoint.
+func (c *BitbucketClient) PutMultipartForm(endpoint string, fields map[string]string) (*http.Response, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	for key, val := range fields {
+		err := writer.WriteField(key, val)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err := writer.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Do("PUT", endpoint, body, writer.FormDataContentType())
+}
+
 func (c *BitbucketClient) Delete(endpoint string) (*http.Response, error) {
 	return c.Do("DELETE", endpoint, nil, "application/json")
 }
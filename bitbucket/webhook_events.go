@@ -0,0 +1,176 @@
+package bitbucket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/xvlcwk-terraform/terraform-provider-bitbucketserver/bitbucket/util/client"
+	bitbucketTypes "github.com/xvlcwk-terraform/terraform-provider-bitbucketserver/bitbucket/util/types"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type WebhookEvent string
+
+const (
+	WebhookEventRepoRefsChanged        WebhookEvent = "repo:refs_changed"
+	WebhookEventRepoModified           WebhookEvent = "repo:modified"
+	WebhookEventRepoForked             WebhookEvent = "repo:forked"
+	WebhookEventRepoCommentAdded       WebhookEvent = "repo:comment:added"
+	WebhookEventRepoCommentEdited      WebhookEvent = "repo:comment:edited"
+	WebhookEventRepoCommentDeleted     WebhookEvent = "repo:comment:deleted"
+	WebhookEventRepoSecretDetected     WebhookEvent = "repo:secret_detected"
+	WebhookEventMirrorRepoSynchronized WebhookEvent = "mirror:repo_synchronized"
+	WebhookEventPrOpened               WebhookEvent = "pr:opened"
+	WebhookEventPrFromRefUpdated       WebhookEvent = "pr:from_ref_updated"
+	WebhookEventPrToRefUpdated         WebhookEvent = "pr:to_ref_updated"
+	WebhookEventPrModified             WebhookEvent = "pr:modified"
+	WebhookEventPrReviewerUpdated      WebhookEvent = "pr:reviewer:updated"
+	WebhookEventPrReviewerApproved     WebhookEvent = "pr:reviewer:approved"
+	WebhookEventPrReviewerUnapproved   WebhookEvent = "pr:reviewer:unapproved"
+	WebhookEventPrReviewerNeedsWork    WebhookEvent = "pr:reviewer:needs_work"
+	WebhookEventPrMerged               WebhookEvent = "pr:merged"
+	WebhookEventPrDeclined             WebhookEvent = "pr:declined"
+	WebhookEventPrDeleted              WebhookEvent = "pr:deleted"
+	WebhookEventPrCommentAdded         WebhookEvent = "pr:comment:added"
+	WebhookEventPrCommentEdited        WebhookEvent = "pr:comment:edited"
+	WebhookEventPrCommentDeleted       WebhookEvent = "pr:comment:deleted"
+)
+
+// webhookEventCatalog maps every known webhook event to the first Bitbucket version supporting it,
+// as listed in the event payload documentation of Bitbucket Data Center.
+var webhookEventCatalog = map[WebhookEvent]string{
+	WebhookEventRepoRefsChanged:        "5.10",
+	WebhookEventRepoModified:           "5.10",
+	WebhookEventRepoForked:             "5.10",
+	WebhookEventRepoCommentAdded:       "5.10",
+	WebhookEventRepoCommentEdited:      "5.10",
+	WebhookEventRepoCommentDeleted:     "5.10",
+	WebhookEventRepoSecretDetected:     "8.3",
+	WebhookEventMirrorRepoSynchronized: "6.0",
+	WebhookEventPrOpened:               "5.10",
+	WebhookEventPrFromRefUpdated:       "5.10",
+	WebhookEventPrToRefUpdated:         "7.0",
+	WebhookEventPrModified:             "5.10",
+	WebhookEventPrReviewerUpdated:      "5.10",
+	WebhookEventPrReviewerApproved:     "5.10",
+	WebhookEventPrReviewerUnapproved:   "5.10",
+	WebhookEventPrReviewerNeedsWork:    "5.10",
+	WebhookEventPrMerged:               "5.10",
+	WebhookEventPrDeclined:             "5.10",
+	WebhookEventPrDeleted:              "5.10",
+	WebhookEventPrCommentAdded:         "5.10",
+	WebhookEventPrCommentEdited:        "5.10",
+	WebhookEventPrCommentDeleted:       "5.10",
+}
+
+// bitbucketVersions caches the version per server, so plans with many webhooks read it only once.
+var bitbucketVersions sync.Map
+
+func validWebhookEvents() []string {
+	events := make([]string, 0, len(webhookEventCatalog))
+	for event := range webhookEventCatalog {
+		events = append(events, string(event))
+	}
+	sort.Strings(events)
+
+	return events
+}
+
+func expandWebhookEvents(events *schema.Set) []WebhookEvent {
+	webhookEvents := make([]WebhookEvent, 0, events.Len())
+	for _, event := range events.List() {
+		webhookEvents = append(webhookEvents, WebhookEvent(event.(string)))
+	}
+
+	return webhookEvents
+}
+
+func collapseWebhookEvents(webhookEvents []WebhookEvent) []string {
+	events := make([]string, 0, len(webhookEvents))
+	for _, event := range webhookEvents {
+		events = append(events, string(event))
+	}
+
+	return events
+}
+
+// validateWebhookEventsVersion fails the plan if an event isn't supported by the Bitbucket version of the server.
+func validateWebhookEventsVersion(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	// unchanged events were already validated, so most plans don't need the version at all
+	if !d.HasChange("events") || !d.NewValueKnown("events") {
+		return nil
+	}
+
+	version, err := readBitbucketVersion(m.(*bitbucketTypes.BitbucketServerProvider).BitbucketClient)
+	if err != nil {
+		// the version is only used for validation, the apply reports unsupported events anyway
+		log.Printf("[WARN] Failed to read the Bitbucket version, skipping webhook event validation: %v", err)
+		return nil
+	}
+
+	for _, event := range d.Get("events").(*schema.Set).List() {
+		minVersion, ok := webhookEventCatalog[WebhookEvent(event.(string))]
+		if !ok {
+			continue
+		}
+
+		if compareVersions(version, minVersion) < 0 {
+			return fmt.Errorf("webhook event %s requires Bitbucket %s or later, the server runs %s", event.(string), minVersion, version)
+		}
+	}
+
+	return nil
+}
+
+func readBitbucketVersion(client *client.BitbucketClient) (string, error) {
+	if version, ok := bitbucketVersions.Load(client.Server); ok {
+		return version.(string), nil
+	}
+
+	resp, err := client.Get("/rest/api/1.0/application-properties")
+	if err != nil {
+		return "", err
+	}
+
+	var applicationProperties ApplicationProperties
+
+	decoder := json.NewDecoder(resp.Body)
+	err = decoder.Decode(&applicationProperties)
+	if err != nil {
+		return "", err
+	}
+
+	bitbucketVersions.Store(client.Server, applicationProperties.Version)
+
+	return applicationProperties.Version, nil
+}
+
+// compareVersions compares dotted versions like 8.9.1 numerically, missing parts count as 0.
+func compareVersions(a string, b string) int {
+	partsA := strings.Split(a, ".")
+	partsB := strings.Split(b, ".")
+
+	for i := 0; i < len(partsA) || i < len(partsB); i++ {
+		var numberA, numberB int
+		if i < len(partsA) {
+			numberA, _ = strconv.Atoi(partsA[i])
+		}
+		if i < len(partsB) {
+			numberB, _ = strconv.Atoi(partsB[i])
+		}
+
+		if numberA != numberB {
+			if numberA < numberB {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	return 0
+}